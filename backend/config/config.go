@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -17,13 +19,22 @@ const (
 	BSROnly
 	// LocalOnly only uses local files, never fetches from BSR
 	LocalOnly
+	// ImageOnly only uses the FileDescriptorSet images in DESCRIPTOR_IMAGE_PATH, never fetches from BSR
+	ImageOnly
+	// Snapshot only uses the snapshot bundles in SNAPSHOT_PATH, never fetches from BSR
+	Snapshot
 )
 
+// UsesBSR reports whether the mode fetches from BSR
+func (m SchemaSourceMode) UsesBSR() bool {
+	return m == LocalThenBSR || m == BSROnly
+}
+
 // GetSchemaSourceMode retrieves the schema source mode from environment variable
 // Context can be "schema" or "validation" (case-insensitive)
 // - For "schema": reads from SCHEMA_SOURCE_MODE env var
 // - For "validation": reads from VALIDATION_SOURCE_MODE env var
-// Supports values: "local-then-bsr", "bsr-only", "local-only" (case-insensitive)
+// Supports values: "local-then-bsr", "bsr-only", "local-only", "image", "snapshot" (case-insensitive)
 // Defaults to LocalThenBSR if not set or invalid
 func GetSchemaSourceMode(context string) SchemaSourceMode {
 	context = strings.ToLower(strings.TrimSpace(context))
@@ -51,6 +62,10 @@ func GetSchemaSourceMode(context string) SchemaSourceMode {
 		return LocalOnly
 	case "local-then-bsr":
 		return LocalThenBSR
+	case "image":
+		return ImageOnly
+	case "snapshot":
+		return Snapshot
 	default:
 		// Default to LocalThenBSR for invalid values
 		return LocalThenBSR
@@ -78,3 +93,47 @@ func GetEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// GetEnvInt retrieves an integer environment variable with a default value
+// Returns the default value if the variable is not set or cannot be parsed
+func GetEnvInt(key string, defaultValue int) int {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+// GetEnvDuration retrieves a duration environment variable with a default value
+// Accepts Go duration strings such as "30s", "5m" or "1h"
+// Returns the default value if the variable is not set or cannot be parsed
+func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+// GetEnvBool retrieves a boolean environment variable with a default value
+// Accepts the values understood by strconv.ParseBool ("true", "false", "1", "0", etc.)
+// Returns the default value if the variable is not set or cannot be parsed
+func GetEnvBool(key string, defaultValue bool) bool {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
@@ -0,0 +1,81 @@
+package connectserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"validation-service/backend/logger"
+)
+
+// Bridge serves gRPC service implementations over the Connect, gRPC-Web and gRPC protocols
+// Every call goes through the same unary interceptor as the gRPC server, so requests are
+// validated identically and errors carry the same status details on every protocol
+type Bridge struct {
+	interceptor grpc.UnaryServerInterceptor
+}
+
+// NewBridge creates a bridge that runs every call through interceptor,
+// normally the validation interceptor installed on the gRPC server
+func NewBridge(interceptor grpc.UnaryServerInterceptor) *Bridge {
+	logger.Debug("Initializing Connect bridge")
+	return &Bridge{
+		interceptor: interceptor,
+	}
+}
+
+// unary calls a gRPC unary method implementation for a Connect request
+// The request headers are exposed to the interceptor and the method as incoming gRPC metadata
+func unary[Req, Res any](ctx context.Context, b *Bridge, req *connect.Request[Req], method func(context.Context, *Req) (*Res, error)) (*connect.Response[Res], error) {
+	procedure := req.Spec().Procedure
+	ctx = metadata.NewIncomingContext(ctx, headerMetadata(req))
+
+	handler := func(ctx context.Context, msg interface{}) (interface{}, error) {
+		return method(ctx, msg.(*Req))
+	}
+	resp, err := b.interceptor(ctx, req.Msg, &grpc.UnaryServerInfo{FullMethod: procedure}, handler)
+	if err != nil {
+		logger.Debug("Connect call %s failed: %v", procedure, err)
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp.(*Res)), nil
+}
+
+// headerMetadata converts the HTTP headers of a Connect request into gRPC metadata
+func headerMetadata[Req any](req *connect.Request[Req]) metadata.MD {
+	md := make(metadata.MD, len(req.Header()))
+	for key, values := range req.Header() {
+		md.Append(strings.ToLower(key), values...)
+	}
+	return md
+}
+
+// connectError converts a gRPC status error, including its details, into a Connect error
+// Connect and gRPC share the same code numbering, so codes map one to one
+func connectError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return connect.NewError(connect.CodeUnknown, err)
+	}
+
+	connectErr := connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	for _, detail := range st.Proto().GetDetails() {
+		msg, err := detail.UnmarshalNew()
+		if err != nil {
+			logger.Warn("Dropping undecodable status detail %s: %v", detail.GetTypeUrl(), err)
+			continue
+		}
+		errorDetail, err := connect.NewErrorDetail(msg)
+		if err != nil {
+			logger.Warn("Dropping status detail %s: %v", detail.GetTypeUrl(), err)
+			continue
+		}
+		connectErr.AddDetail(errorDetail)
+	}
+	return connectErr
+}
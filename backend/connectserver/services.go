@@ -0,0 +1,105 @@
+package connectserver
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	pb "validation-service/backend/proto"
+	"validation-service/backend/proto/protoconnect"
+	taskv1 "validation-service/backend/proto/task/v1"
+	"validation-service/backend/proto/task/v1/taskv1connect"
+	validationv1 "validation-service/backend/proto/validation/v1"
+	"validation-service/backend/proto/validation/v1/validationv1connect"
+)
+
+// GreetingService serves a GreetingServiceServer as a Connect handler
+type GreetingService struct {
+	protoconnect.UnimplementedGreetingServiceHandler
+	bridge *Bridge
+	server pb.GreetingServiceServer
+}
+
+// NewGreetingService creates a Connect handler for server
+func NewGreetingService(bridge *Bridge, server pb.GreetingServiceServer) *GreetingService {
+	return &GreetingService{bridge: bridge, server: server}
+}
+
+// SayHello implements the SayHello RPC method
+func (s *GreetingService) SayHello(ctx context.Context, req *connect.Request[pb.HelloRequest]) (*connect.Response[pb.HelloResponse], error) {
+	return unary(ctx, s.bridge, req, s.server.SayHello)
+}
+
+// ValidationService serves a ValidationServiceServer as a Connect handler
+type ValidationService struct {
+	validationv1connect.UnimplementedValidationServiceHandler
+	bridge *Bridge
+	server validationv1.ValidationServiceServer
+}
+
+// NewValidationService creates a Connect handler for server
+func NewValidationService(bridge *Bridge, server validationv1.ValidationServiceServer) *ValidationService {
+	return &ValidationService{bridge: bridge, server: server}
+}
+
+// ValidateProto implements the ValidateProto RPC method
+func (s *ValidationService) ValidateProto(ctx context.Context, req *connect.Request[validationv1.ValidateProtoRequest]) (*connect.Response[validationv1.ValidateProtoResponse], error) {
+	return unary(ctx, s.bridge, req, s.server.ValidateProto)
+}
+
+// ValidateBatch implements the ValidateBatch RPC method
+func (s *ValidationService) ValidateBatch(ctx context.Context, req *connect.Request[validationv1.ValidateBatchRequest]) (*connect.Response[validationv1.ValidateBatchResponse], error) {
+	return unary(ctx, s.bridge, req, s.server.ValidateBatch)
+}
+
+// GetSchema implements the GetSchema RPC method
+func (s *ValidationService) GetSchema(ctx context.Context, req *connect.Request[validationv1.GetSchemaRequest]) (*connect.Response[validationv1.GetSchemaResponse], error) {
+	return unary(ctx, s.bridge, req, s.server.GetSchema)
+}
+
+// ListMessages implements the ListMessages RPC method
+func (s *ValidationService) ListMessages(ctx context.Context, req *connect.Request[validationv1.ListMessagesRequest]) (*connect.Response[validationv1.ListMessagesResponse], error) {
+	return unary(ctx, s.bridge, req, s.server.ListMessages)
+}
+
+// ListCommits implements the ListCommits RPC method
+func (s *ValidationService) ListCommits(ctx context.Context, req *connect.Request[validationv1.ListCommitsRequest]) (*connect.Response[validationv1.ListCommitsResponse], error) {
+	return unary(ctx, s.bridge, req, s.server.ListCommits)
+}
+
+// TaskService serves a TaskServiceServer as a Connect handler
+type TaskService struct {
+	taskv1connect.UnimplementedTaskServiceHandler
+	bridge *Bridge
+	server taskv1.TaskServiceServer
+}
+
+// NewTaskService creates a Connect handler for server
+func NewTaskService(bridge *Bridge, server taskv1.TaskServiceServer) *TaskService {
+	return &TaskService{bridge: bridge, server: server}
+}
+
+// CreateTask implements the CreateTask RPC method
+func (s *TaskService) CreateTask(ctx context.Context, req *connect.Request[taskv1.CreateTaskRequest]) (*connect.Response[taskv1.TaskRecord], error) {
+	return unary(ctx, s.bridge, req, s.server.CreateTask)
+}
+
+// GetTask implements the GetTask RPC method
+func (s *TaskService) GetTask(ctx context.Context, req *connect.Request[taskv1.GetTaskRequest]) (*connect.Response[taskv1.TaskRecord], error) {
+	return unary(ctx, s.bridge, req, s.server.GetTask)
+}
+
+// ListTasks implements the ListTasks RPC method
+func (s *TaskService) ListTasks(ctx context.Context, req *connect.Request[taskv1.ListTasksRequest]) (*connect.Response[taskv1.ListTasksResponse], error) {
+	return unary(ctx, s.bridge, req, s.server.ListTasks)
+}
+
+// UpdateTaskStatus implements the UpdateTaskStatus RPC method
+func (s *TaskService) UpdateTaskStatus(ctx context.Context, req *connect.Request[taskv1.UpdateTaskStatusRequest]) (*connect.Response[taskv1.TaskRecord], error) {
+	return unary(ctx, s.bridge, req, s.server.UpdateTaskStatus)
+}
+
+// DeleteTask implements the DeleteTask RPC method
+func (s *TaskService) DeleteTask(ctx context.Context, req *connect.Request[pb.DeleteTask]) (*connect.Response[taskv1.DeleteTaskResponse], error) {
+	return unary(ctx, s.bridge, req, s.server.DeleteTask)
+}
@@ -0,0 +1,42 @@
+package grpcserver
+
+import (
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"validation-service/backend/logger"
+	"validation-service/backend/service"
+)
+
+// statusFromError converts a service error into a gRPC status error
+// Validation, schema and task errors are classified by the sentinels they wrap, like in the
+// HTTP handlers; only errors of the commits service are still classified by their message
+func statusFromError(err error) error {
+	errorMsg := err.Error()
+
+	switch {
+	case errors.Is(err, service.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, errorMsg)
+	case errors.Is(err, service.ErrVersionUnavailable):
+		return status.Error(codes.FailedPrecondition, errorMsg)
+	case errors.Is(err, service.ErrNotFound):
+		return status.Error(codes.NotFound, errorMsg)
+	case errors.Is(err, service.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, errorMsg)
+	case errors.Is(err, service.ErrSourceUnavailable):
+		logger.Error("Source failure: %v", err)
+		return status.Error(codes.Unavailable, errorMsg)
+	case strings.Contains(errorMsg, "not found"):
+		return status.Error(codes.NotFound, errorMsg)
+	case strings.Contains(errorMsg, "unauthorized"):
+		return status.Error(codes.Unauthenticated, errorMsg)
+	case strings.Contains(errorMsg, "HTTP request failed") || strings.Contains(errorMsg, "status code"):
+		return status.Error(codes.Unavailable, errorMsg)
+	default:
+		logger.Error("Internal server error: %v", err)
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
@@ -0,0 +1,137 @@
+package grpcserver
+
+import (
+	"context"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"validation-service/backend/logger"
+)
+
+// interceptorConfig holds the settings shared by the validation interceptors
+type interceptorConfig struct {
+	validateResponses bool
+	skipMethods       map[string]bool
+}
+
+// InterceptorOption configures the validation interceptors
+type InterceptorOption func(*interceptorConfig)
+
+// WithResponseValidation also validates every outbound response
+// A response that fails validation is a server bug, so the call fails with INTERNAL
+func WithResponseValidation() InterceptorOption {
+	return func(c *interceptorConfig) {
+		c.validateResponses = true
+	}
+}
+
+// WithSkipMethods disables validation for the given full method names,
+// e.g. "/proto.GreetingService/SayHello"
+func WithSkipMethods(fullMethods ...string) InterceptorOption {
+	return func(c *interceptorConfig) {
+		for _, method := range fullMethods {
+			c.skipMethods[method] = true
+		}
+	}
+}
+
+// newInterceptorConfig applies opts to the default settings
+func newInterceptorConfig(opts []InterceptorOption) *interceptorConfig {
+	c := &interceptorConfig{skipMethods: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// UnaryServerInterceptor validates every inbound request with validator before calling the handler
+// Invalid requests are rejected with INVALID_ARGUMENT and the details built by ValidationStatus
+func UnaryServerInterceptor(validator protovalidate.Validator, opts ...InterceptorOption) grpc.UnaryServerInterceptor {
+	c := newInterceptorConfig(opts)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if c.skipMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		if err := validateMessage(validator, req); err != nil {
+			logger.Debug("Rejected request for %s: %v", info.FullMethod, err)
+			return nil, ValidationStatus(err)
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil || !c.validateResponses {
+			return resp, err
+		}
+		if err := validateMessage(validator, resp); err != nil {
+			return nil, responseStatus(info.FullMethod, err)
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor validates every message received on a stream, and every message
+// sent if response validation is enabled
+func StreamServerInterceptor(validator protovalidate.Validator, opts ...InterceptorOption) grpc.StreamServerInterceptor {
+	c := newInterceptorConfig(opts)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if c.skipMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		return handler(srv, &validatingServerStream{
+			ServerStream: ss,
+			validator:    validator,
+			fullMethod:   info.FullMethod,
+			config:       c,
+		})
+	}
+}
+
+// validatingServerStream wraps a server stream to validate the messages passing through it
+type validatingServerStream struct {
+	grpc.ServerStream
+	validator  protovalidate.Validator
+	fullMethod string
+	config     *interceptorConfig
+}
+
+// RecvMsg receives the next request and validates it
+func (s *validatingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := validateMessage(s.validator, m); err != nil {
+		logger.Debug("Rejected stream message for %s: %v", s.fullMethod, err)
+		return ValidationStatus(err)
+	}
+	return nil
+}
+
+// SendMsg validates a response, if enabled, before sending it
+func (s *validatingServerStream) SendMsg(m interface{}) error {
+	if s.config.validateResponses {
+		if err := validateMessage(s.validator, m); err != nil {
+			return responseStatus(s.fullMethod, err)
+		}
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+// validateMessage validates m if it is a protobuf message
+func validateMessage(validator protovalidate.Validator, m interface{}) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+	return validator.Validate(msg)
+}
+
+// responseStatus reports an invalid outbound response as an INTERNAL error
+// The violations are logged rather than returned, they describe the server, not the client request
+func responseStatus(fullMethod string, err error) error {
+	logger.Error("Invalid response from %s: %v", fullMethod, err)
+	return status.Error(codes.Internal, "server produced an invalid response")
+}
@@ -0,0 +1,111 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "validation-service/backend/proto"
+)
+
+const sayHelloMethod = "/proto.GreetingService/SayHello"
+
+func newTestValidator(t *testing.T) protovalidate.Validator {
+	validator, err := protovalidate.New()
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+	return validator
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	validator := newTestValidator(t)
+	info := &grpc.UnaryServerInfo{FullMethod: sayHelloMethod}
+	echo := func(response interface{}) grpc.UnaryHandler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			return response, nil
+		}
+	}
+
+	tests := []struct {
+		name     string
+		opts     []InterceptorOption
+		req      *pb.HelloRequest
+		resp     *pb.HelloRequest
+		wantCode codes.Code
+	}{
+		{name: "valid request", req: &pb.HelloRequest{Name: "John"}, resp: &pb.HelloRequest{Name: "x"}},
+		{name: "invalid request", req: &pb.HelloRequest{Name: "Jo"}, wantCode: codes.InvalidArgument},
+		{name: "skipped method", opts: []InterceptorOption{WithSkipMethods(sayHelloMethod)}, req: &pb.HelloRequest{Name: "Jo"}},
+		{name: "invalid response", opts: []InterceptorOption{WithResponseValidation()}, req: &pb.HelloRequest{Name: "John"}, resp: &pb.HelloRequest{Name: "x"}, wantCode: codes.Internal},
+		{name: "valid response", opts: []InterceptorOption{WithResponseValidation()}, req: &pb.HelloRequest{Name: "John"}, resp: &pb.HelloRequest{Name: "John"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := UnaryServerInterceptor(validator, tt.opts...)
+			_, err := interceptor(context.Background(), tt.req, info, echo(tt.resp))
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s (%v)", tt.wantCode, code, err)
+			}
+		})
+	}
+}
+
+// fakeServerStream replays queued requests and records sent responses
+type fakeServerStream struct {
+	grpc.ServerStream
+	requests []*pb.HelloRequest
+	sent     int
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	next := s.requests[0]
+	s.requests = s.requests[1:]
+	m.(*pb.HelloRequest).Name = next.GetName()
+	return nil
+}
+
+func (s *fakeServerStream) SendMsg(m interface{}) error {
+	s.sent++
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	validator := newTestValidator(t)
+	info := &grpc.StreamServerInfo{FullMethod: "/proto.GreetingService/SayHelloStream"}
+
+	stream := &fakeServerStream{requests: []*pb.HelloRequest{{Name: "John"}, {Name: "Jo"}}}
+	var recvErrs []error
+	var sendErr error
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		for i := 0; i < 2; i++ {
+			recvErrs = append(recvErrs, ss.RecvMsg(&pb.HelloRequest{}))
+		}
+		sendErr = ss.SendMsg(&pb.HelloRequest{Name: "x"})
+		return nil
+	}
+
+	interceptor := StreamServerInterceptor(validator, WithResponseValidation())
+	if err := interceptor(nil, stream, info, handler); err != nil {
+		t.Fatalf("Unexpected handler error: %v", err)
+	}
+
+	if recvErrs[0] != nil {
+		t.Errorf("Expected first message to be valid, got %v", recvErrs[0])
+	}
+	if status.Code(recvErrs[1]) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for second message, got %v", recvErrs[1])
+	}
+	if status.Code(sendErr) != codes.Internal || stream.sent != 0 {
+		t.Errorf("Expected invalid response to be rejected before sending, got %v (sent %d)", sendErr, stream.sent)
+	}
+}
@@ -0,0 +1,51 @@
+package grpcserver
+
+import (
+	"errors"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"validation-service/backend/logger"
+)
+
+// ValidationStatus converts an error returned by protovalidate.Validator.Validate into a gRPC status error
+// Validation failures become INVALID_ARGUMENT with two details attached so clients can map
+// errors to fields programmatically:
+//   - buf.validate.Violations, the full protovalidate violations
+//   - google.rpc.BadRequest, one field violation per protovalidate violation
+//
+// Other errors (e.g. CEL compilation or runtime errors) are server-side problems and become INTERNAL
+func ValidationStatus(err error) error {
+	var validationErr *protovalidate.ValidationError
+	if !errors.As(err, &validationErr) {
+		logger.Error("Failed to validate request: %v", err)
+		return status.Errorf(codes.Internal, "failed to validate request: %v", err)
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range validationErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       protovalidate.FieldPathString(violation.Proto.GetField()),
+			Description: violation.Proto.GetMessage(),
+			Reason:      violationReason(violation.Proto.GetRuleId()),
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, "validation failed: "+err.Error())
+	detailed, detailsErr := st.WithDetails(validationErr.ToProto(), badRequest)
+	if detailsErr != nil {
+		logger.Error("Failed to attach validation details to status: %v", detailsErr)
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// violationReason converts a protovalidate rule id into the UPPER_SNAKE_CASE reason
+// format expected by google.rpc.BadRequest, e.g. "string.min_len" becomes "STRING_MIN_LEN"
+func violationReason(ruleID string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(ruleID))
+}
@@ -0,0 +1,73 @@
+package grpcserver
+
+import (
+	"context"
+
+	"validation-service/backend/logger"
+	pb "validation-service/backend/proto"
+	taskv1 "validation-service/backend/proto/task/v1"
+	"validation-service/backend/service"
+)
+
+// TaskServer implements the TaskServiceServer interface on top of the TaskService
+// shared with the HTTP task handler
+type TaskServer struct {
+	taskv1.UnimplementedTaskServiceServer
+	taskService *service.TaskService
+}
+
+// NewTaskServer creates a new gRPC task server
+func NewTaskServer(taskService *service.TaskService) *TaskServer {
+	logger.Debug("Initializing TaskServer")
+	return &TaskServer{
+		taskService: taskService,
+	}
+}
+
+// CreateTask implements the CreateTask RPC method
+func (s *TaskServer) CreateTask(ctx context.Context, req *taskv1.CreateTaskRequest) (*taskv1.TaskRecord, error) {
+	record, err := s.taskService.CreateTask(req)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+	return record, nil
+}
+
+// GetTask implements the GetTask RPC method
+func (s *TaskServer) GetTask(ctx context.Context, req *taskv1.GetTaskRequest) (*taskv1.TaskRecord, error) {
+	record, err := s.taskService.GetTask(req)
+	if err != nil {
+		logger.Debug("Task retrieval failed for id=%s: %v", req.GetId(), err)
+		return nil, statusFromError(err)
+	}
+	return record, nil
+}
+
+// ListTasks implements the ListTasks RPC method
+func (s *TaskServer) ListTasks(ctx context.Context, req *taskv1.ListTasksRequest) (*taskv1.ListTasksResponse, error) {
+	response, err := s.taskService.ListTasks(req)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+	return response, nil
+}
+
+// UpdateTaskStatus implements the UpdateTaskStatus RPC method
+func (s *TaskServer) UpdateTaskStatus(ctx context.Context, req *taskv1.UpdateTaskStatusRequest) (*taskv1.TaskRecord, error) {
+	record, err := s.taskService.UpdateTaskStatus(req)
+	if err != nil {
+		logger.Debug("Task update failed for id=%s: %v", req.GetId(), err)
+		return nil, statusFromError(err)
+	}
+	return record, nil
+}
+
+// DeleteTask implements the DeleteTask RPC method
+func (s *TaskServer) DeleteTask(ctx context.Context, req *pb.DeleteTask) (*taskv1.DeleteTaskResponse, error) {
+	record, err := s.taskService.DeleteTask(req)
+	if err != nil {
+		logger.Debug("Task deletion failed for id=%s: %v", req.GetTaskId(), err)
+		return nil, statusFromError(err)
+	}
+	return &taskv1.DeleteTaskResponse{Task: record}, nil
+}
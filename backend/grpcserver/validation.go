@@ -0,0 +1,279 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"validation-service/backend/logger"
+	validationv1 "validation-service/backend/proto/validation/v1"
+	"validation-service/backend/service"
+)
+
+// defaultCommitsPageSize matches the default page size of GET /api/v1/commits
+const defaultCommitsPageSize = 26
+
+// ValidationServer implements the ValidationServiceServer interface on top of the same
+// services used by the HTTP handlers
+type ValidationServer struct {
+	validationv1.UnimplementedValidationServiceServer
+	validationService *service.ValidationService
+	schemaService     *service.SchemaService
+	commitsService    *service.CommitsService
+	batchWorkers      int
+	maxBatchItems     int
+}
+
+// NewValidationServer creates a new gRPC validation server
+// batchWorkers and maxBatchItems have the same meaning as for the HTTP batch endpoint
+func NewValidationServer(validationService *service.ValidationService, schemaService *service.SchemaService, commitsService *service.CommitsService, batchWorkers, maxBatchItems int) *ValidationServer {
+	logger.Debug("Initializing ValidationServer with batchWorkers=%d, maxBatchItems=%d", batchWorkers, maxBatchItems)
+	return &ValidationServer{
+		validationService: validationService,
+		schemaService:     schemaService,
+		commitsService:    commitsService,
+		batchWorkers:      batchWorkers,
+		maxBatchItems:     maxBatchItems,
+	}
+}
+
+// ValidateProto implements the ValidateProto RPC method
+func (s *ValidationServer) ValidateProto(ctx context.Context, req *validationv1.ValidateProtoRequest) (*validationv1.ValidateProtoResponse, error) {
+	payload, format := requestPayload(req.GetPayload())
+	opts := service.ValidateOptions{Format: format, Strict: req.Strict}
+	logger.Info("Processing gRPC validation request for schemaName=%s, format=%s, commit=%s", req.GetSchemaName(), format, req.GetCommit())
+
+	success, errors, resolution, err := s.validationService.ValidatePayload(req.GetSchemaName(), payload, req.GetCommit(), opts)
+	if err != nil {
+		logger.Debug("Validation service error for schemaName=%s: %v", req.GetSchemaName(), err)
+		return nil, statusFromError(err)
+	}
+
+	return &validationv1.ValidateProtoResponse{
+		Success:    success,
+		Errors:     toProtoErrors(errors),
+		Resolution: toProtoResolution(resolution),
+	}, nil
+}
+
+// ValidateBatch implements the ValidateBatch RPC method
+func (s *ValidationServer) ValidateBatch(ctx context.Context, req *validationv1.ValidateBatchRequest) (*validationv1.ValidateBatchResponse, error) {
+	if s.maxBatchItems > 0 && len(req.GetItems()) > s.maxBatchItems {
+		return nil, status.Errorf(codes.InvalidArgument, "batch contains %d items, maximum is %d", len(req.GetItems()), s.maxBatchItems)
+	}
+
+	items := make([]service.BatchItem, 0, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		schemaName := item.GetSchemaName()
+		if schemaName == "" {
+			schemaName = req.GetSchemaName()
+		}
+		if schemaName == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "items[%d]: schema_name is required", i)
+		}
+		commit := item.GetCommit()
+		if commit == "" {
+			commit = req.GetCommit()
+		}
+		strict := item.Strict
+		if strict == nil {
+			strict = req.Strict
+		}
+
+		payload, format := requestPayload(item.GetPayload())
+		items = append(items, service.BatchItem{
+			SchemaName: schemaName,
+			Payload:    payload,
+			Commit:     commit,
+			Options:    service.ValidateOptions{Format: format, Strict: strict},
+		})
+	}
+
+	logger.Info("Processing gRPC batch validation request with %d item(s)", len(items))
+	results := s.validationService.ValidateBatch(items, s.batchWorkers)
+
+	response := &validationv1.ValidateBatchResponse{
+		Success: true,
+		Total:   int32(len(results)),
+		Results: make([]*validationv1.BatchResult, 0, len(results)),
+	}
+	for _, result := range results {
+		if result.Success {
+			response.Valid++
+		} else {
+			response.Invalid++
+			response.Success = false
+		}
+		response.Results = append(response.Results, &validationv1.BatchResult{
+			Index:      int32(result.Index),
+			Success:    result.Success,
+			Errors:     toProtoErrors(result.Errors),
+			Error:      result.Error,
+			Resolution: toProtoResolution(result.Resolution),
+		})
+	}
+
+	logger.Info("gRPC batch validation completed: total=%d, valid=%d, invalid=%d", response.Total, response.Valid, response.Invalid)
+	return response, nil
+}
+
+// GetSchema implements the GetSchema RPC method
+func (s *ValidationServer) GetSchema(ctx context.Context, req *validationv1.GetSchemaRequest) (*validationv1.GetSchemaResponse, error) {
+	opts := service.SchemaOptions{
+		Variant: service.SchemaVariant{
+			JSONNames: req.GetFieldNames() == validationv1.FieldNames_FIELD_NAMES_JSON,
+			Strict:    req.GetStrict(),
+			Unbundled: req.Bundle != nil && !req.GetBundle(),
+		},
+		ResolveRefs: req.GetResolveRefs(),
+	}
+	schema, err := s.schemaService.GetSchema(req.GetMessageName(), req.GetCommit(), opts)
+	if err != nil {
+		logger.Debug("Schema retrieval failed for messageName=%s: %v", req.GetMessageName(), err)
+		return nil, statusFromError(err)
+	}
+
+	return &validationv1.GetSchemaResponse{
+		JsonSchema: string(schema.Data),
+		Resolution: toProtoResolution(schema.Resolution()),
+	}, nil
+}
+
+// ListMessages implements the ListMessages RPC method
+func (s *ValidationServer) ListMessages(ctx context.Context, req *validationv1.ListMessagesRequest) (*validationv1.ListMessagesResponse, error) {
+	protoFiles, err := s.schemaService.ListProtoFiles()
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	response := &validationv1.ListMessagesResponse{
+		Messages: make([]*validationv1.MessageInfo, 0, len(protoFiles)),
+	}
+	for _, protoFile := range protoFiles {
+		response.Messages = append(response.Messages, &validationv1.MessageInfo{
+			Name:        protoFile.Name,
+			Description: protoFile.Description,
+			FullName:    protoFile.FullyQualifiedName,
+			Version:     protoFile.Version,
+		})
+	}
+	return response, nil
+}
+
+// ListCommits implements the ListCommits RPC method
+func (s *ValidationServer) ListCommits(ctx context.Context, req *validationv1.ListCommitsRequest) (*validationv1.ListCommitsResponse, error) {
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultCommitsPageSize
+	}
+	label := req.GetLabel()
+	if label == "" {
+		label = "main"
+	}
+
+	commitsResponse, err := s.commitsService.ListCommits(pageSize, label, req.GetPageToken())
+	if err != nil {
+		logger.Debug("Commits retrieval failed: %v", err)
+		return nil, statusFromError(err)
+	}
+
+	response := &validationv1.ListCommitsResponse{
+		NextPageToken: commitsResponse.NextPageToken,
+		Commits:       make([]*validationv1.CommitInfo, 0, len(commitsResponse.Values)),
+	}
+	for _, value := range commitsResponse.Values {
+		if value.Commit == nil {
+			continue
+		}
+		commit := &validationv1.CommitInfo{Id: value.Commit.ID}
+		if createTime, err := time.Parse(time.RFC3339Nano, value.Commit.CreateTime); err == nil {
+			commit.CreateTime = timestamppb.New(createTime)
+		}
+		if value.Commit.Digest != nil {
+			commit.DigestType = value.Commit.Digest.Type
+			commit.DigestValue = value.Commit.Digest.Value
+		}
+		if value.CommitCheckState != nil {
+			commit.CheckStatus = value.CommitCheckState.Status
+		}
+		response.Commits = append(response.Commits, commit)
+	}
+	return response, nil
+}
+
+// requestPayload returns the payload set in the payload oneof of a request or batch item and its format
+func requestPayload(payload interface{}) ([]byte, service.PayloadFormat) {
+	switch p := payload.(type) {
+	case *validationv1.ValidateProtoRequest_PayloadJson:
+		return []byte(p.PayloadJson), service.PayloadFormatJSON
+	case *validationv1.ValidateProtoRequest_PayloadText:
+		return []byte(p.PayloadText), service.PayloadFormatText
+	case *validationv1.ValidateProtoRequest_PayloadBinary:
+		return p.PayloadBinary, service.PayloadFormatBinary
+	case *validationv1.ValidateBatchItem_PayloadJson:
+		return []byte(p.PayloadJson), service.PayloadFormatJSON
+	case *validationv1.ValidateBatchItem_PayloadText:
+		return []byte(p.PayloadText), service.PayloadFormatText
+	case *validationv1.ValidateBatchItem_PayloadBinary:
+		return p.PayloadBinary, service.PayloadFormatBinary
+	default:
+		return nil, service.PayloadFormatBinary
+	}
+}
+
+// toProtoResolution converts a service resolution into its protobuf representation
+// Returns nil for an empty resolution, e.g. of a batch item whose schema was not resolved
+func toProtoResolution(resolution service.Resolution) *validationv1.Resolution {
+	if resolution == (service.Resolution{}) {
+		return nil
+	}
+	return &validationv1.Resolution{
+		ResolvedCommit: resolution.Commit,
+		Source:         resolution.Source,
+		Digest:         resolution.Digest,
+		ModuleDigest:   resolution.ModuleDigest,
+		Stale:          resolution.Stale,
+	}
+}
+
+// toProtoErrors converts service validation errors into their protobuf representation
+func toProtoErrors(errors []service.ValidationError) []*validationv1.ValidationError {
+	protoErrors := make([]*validationv1.ValidationError, 0, len(errors))
+	for _, e := range errors {
+		protoErrors = append(protoErrors, &validationv1.ValidationError{
+			Friendly:     e.Friendly,
+			Technical:    e.Technical,
+			Field:        e.Field,
+			JsonField:    e.JSONField,
+			Pointer:      e.Pointer,
+			RuleId:       e.RuleID,
+			ConstraintId: e.ConstraintID,
+			Value:        toProtoValue(e.Value),
+			ForKey:       e.ForKey,
+		})
+	}
+	return protoErrors
+}
+
+// toProtoValue converts the JSON-friendly value of a violation into a google.protobuf.Value
+// Returns nil if there is no value
+func toProtoValue(value interface{}) *structpb.Value {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	protoValue := &structpb.Value{}
+	if err := protojson.Unmarshal(data, protoValue); err != nil {
+		return nil
+	}
+	return protoValue
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"validation-service/backend/logger"
+	"validation-service/backend/service"
+)
+
+// descriptorsPath is the collection route of the descriptor registration API
+const descriptorsPath = "/api/v1/descriptors"
+
+// maxDescriptorSetBytes limits the size of a registered FileDescriptorSet
+const maxDescriptorSetBytes = 32 << 20
+
+// descriptorSetContentTypes are the content types of binary FileDescriptorSets,
+// application/json is the JSON encoding
+var descriptorSetContentTypes = map[string]bool{
+	"application/x-protobuf":   true,
+	"application/protobuf":     true,
+	"application/octet-stream": true,
+}
+
+// DescriptorsHandler handles HTTP requests for registering descriptor sets at runtime
+type DescriptorsHandler struct {
+	registry            *service.DescriptorRegistry
+	registrationEnabled bool
+}
+
+// NewDescriptorsHandler creates a new descriptors handler
+// Registering and deleting sets is refused with 403 unless registrationEnabled is set,
+// listing and describing them is always allowed
+func NewDescriptorsHandler(registry *service.DescriptorRegistry, registrationEnabled bool) *DescriptorsHandler {
+	return &DescriptorsHandler{
+		registry:            registry,
+		registrationEnabled: registrationEnabled,
+	}
+}
+
+// Descriptors handles GET (list) and POST (register) on /api/v1/descriptors
+// POST takes the version to register as the version query parameter and the
+// FileDescriptorSet as the body, binary or JSON by its Content-Type
+func (h *DescriptorsHandler) Descriptors(w http.ResponseWriter, r *http.Request) {
+	logger.Debug("Received request: method=%s, path=%s, remote=%s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	switch r.Method {
+	case http.MethodGet:
+		h.writeJSON(w, http.StatusOK, h.registry.List())
+	case http.MethodPost:
+		if h.refuseWrite(w) {
+			return
+		}
+		h.register(w, r)
+	default:
+		logger.Debug("Method not allowed: %s (expected GET or POST)", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Descriptor handles GET and DELETE on /api/v1/descriptors/{version}
+func (h *DescriptorsHandler) Descriptor(w http.ResponseWriter, r *http.Request) {
+	logger.Debug("Received request: method=%s, path=%s, remote=%s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	version, err := url.PathUnescape(strings.TrimPrefix(r.URL.Path, descriptorsPath+"/"))
+	if err != nil || version == "" || strings.Contains(version, "/") {
+		logger.Debug("Invalid descriptors path: %s", r.URL.Path)
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		registered, err := h.registry.Get(version)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		h.writeJSON(w, http.StatusOK, registered)
+	case http.MethodDelete:
+		if h.refuseWrite(w) {
+			return
+		}
+		if err := h.registry.Delete(version); err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		logger.Debug("Method not allowed: %s (expected GET or DELETE)", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// register handles POST /api/v1/descriptors?version={version}
+func (h *DescriptorsHandler) register(w http.ResponseWriter, r *http.Request) {
+	version := r.URL.Query().Get("version")
+	if version == "" {
+		logger.Debug("Missing required parameter: version")
+		http.Error(w, "version is required", http.StatusBadRequest)
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	jsonEncoded := mediaType == "application/json"
+	if !jsonEncoded && !descriptorSetContentTypes[mediaType] {
+		logger.Debug("Unsupported descriptor set content type: %s", mediaType)
+		http.Error(w, "Content-Type must be application/x-protobuf or application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxDescriptorSetBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "FileDescriptorSet too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		logger.Debug("Failed to read request body: %v", err)
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	logger.Info("Processing descriptor registration: version=%s, size=%d bytes, json=%v", version, len(body), jsonEncoded)
+	registered, err := h.registry.Register(version, body, jsonEncoded)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusCreated, registered)
+}
+
+// refuseWrite writes 403 and returns true if registration is disabled
+func (h *DescriptorsHandler) refuseWrite(w http.ResponseWriter) bool {
+	if h.registrationEnabled {
+		return false
+	}
+	logger.Debug("Returning 403 Forbidden: descriptor registration is disabled")
+	http.Error(w, "Descriptor registration is disabled, set DESCRIPTOR_REGISTRATION_ENABLED=true to enable it", http.StatusForbidden)
+	return true
+}
+
+// writeJSON writes v as a JSON response
+func (h *DescriptorsHandler) writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Error("Failed to encode descriptors response: %v", err)
+	}
+}
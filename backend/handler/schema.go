@@ -2,8 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"validation-service/backend/logger"
 	"validation-service/backend/service"
@@ -21,7 +23,10 @@ func NewSchemaHandler(schemaService *service.SchemaService) *SchemaHandler {
 	}
 }
 
-// GetSchema handles GET /api/v1/schema/{messageName}
+// GetSchema handles GET /api/v1/schema/{messageName}?commit={commit}&names={names}&strict={strict}&bundle={bundle}&resolveRefs={resolveRefs}
+// commit is an optional BSR commit ID or label, defaults to "main"
+// names ("proto" or "json"), strict (default false) and bundle (default true) select the
+// generated variant, resolveRefs bundles the "$ref"s of an unbundled variant on demand
 func (h *SchemaHandler) GetSchema(w http.ResponseWriter, r *http.Request) {
 	logger.Debug("Received request: method=%s, path=%s, remote=%s", r.Method, r.URL.Path, r.RemoteAddr)
 
@@ -62,15 +67,25 @@ func (h *SchemaHandler) GetSchema(w http.ResponseWriter, r *http.Request) {
 	}
 	messageName = decodedName
 
-	logger.Info("Processing schema request for messageName=%s", messageName)
+	query := r.URL.Query()
+	commit := query.Get("commit")
+	opts, err := parseSchemaOptions(query)
+	if err != nil {
+		logger.Debug("Invalid schema query parameters: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	logger.Info("Processing schema request for messageName=%s, commit=%s, variant=%s, resolveRefs=%v", messageName, commit, opts.Variant, opts.ResolveRefs)
 
 	// Get schema from service
-	schemaData, err := h.schemaService.GetSchema(messageName)
+	schema, err := h.schemaService.GetSchema(messageName, commit, opts)
 	if err != nil {
 		logger.Debug("Schema retrieval failed for messageName=%s: %v", messageName, err)
-		h.handleError(w, err)
+		writeServiceError(w, err)
 		return
 	}
+	schemaData := schema.Data
 
 	// Parse JSON to validate it's valid JSON
 	var schemaJSON interface{}
@@ -81,8 +96,10 @@ func (h *SchemaHandler) GetSchema(w http.ResponseWriter, r *http.Request) {
 		logger.Debug("Schema JSON validation passed for messageName=%s", messageName)
 	}
 
-	// Set response headers
+	// Set response headers, the body is the schema document itself so where it came from
+	// is reported in headers
 	w.Header().Set("Content-Type", "application/json")
+	setResolutionHeaders(w, schema.Resolution())
 	w.WriteHeader(http.StatusOK)
 
 	// Write response
@@ -128,20 +145,63 @@ func (h *SchemaHandler) ListProtoFiles(w http.ResponseWriter, r *http.Request) {
 	logger.Info("Successfully returned %d proto file(s)", len(protoFiles))
 }
 
-// handleError handles errors and returns appropriate HTTP status codes
-func (h *SchemaHandler) handleError(w http.ResponseWriter, err error) {
-	errorMsg := err.Error()
-
-	// Determine status code based on error message
-	switch {
-	case strings.Contains(errorMsg, "invalid message name") || strings.Contains(errorMsg, "cannot be empty"):
-		logger.Debug("Returning 400 Bad Request: %s", errorMsg)
-		http.Error(w, errorMsg, http.StatusBadRequest)
-	case strings.Contains(errorMsg, "not found") || strings.Contains(errorMsg, "not found in BSR"):
-		logger.Debug("Returning 404 Not Found: %s", errorMsg)
-		http.Error(w, errorMsg, http.StatusNotFound)
-	default:
-		logger.Error("Internal server error: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+// parseSchemaOptions reads the schema variant query parameters
+func parseSchemaOptions(query url.Values) (service.SchemaOptions, error) {
+	var opts service.SchemaOptions
+	var err error
+
+	if opts.Variant.JSONNames, err = service.ParseFieldNames(query.Get("names")); err != nil {
+		return opts, err
+	}
+	if opts.Variant.Strict, err = parseBoolParam(query, "strict", false); err != nil {
+		return opts, err
+	}
+	bundle, err := parseBoolParam(query, "bundle", true)
+	if err != nil {
+		return opts, err
+	}
+	opts.Variant.Unbundled = !bundle
+	if opts.ResolveRefs, err = parseBoolParam(query, "resolveRefs", false); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// parseBoolParam parses the boolean query parameter name, defaulting to def if it is absent
+func parseBoolParam(query url.Values, name string, def bool) (bool, error) {
+	value := query.Get(name)
+	if value == "" {
+		return def, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s parameter %q: must be true or false", name, value)
+	}
+	return parsed, nil
+}
+
+// Response headers reporting where a schema came from, see service.Resolution
+const (
+	headerSchemaCommit       = "X-Schema-Commit"
+	headerSchemaSource       = "X-Schema-Source"
+	headerSchemaDigest       = "X-Schema-Digest"
+	headerSchemaModuleDigest = "X-Schema-Module-Digest"
+	headerSchemaStale        = "X-Schema-Stale"
+)
+
+// setResolutionHeaders sets the schema resolution headers, omitting empty values
+func setResolutionHeaders(w http.ResponseWriter, resolution service.Resolution) {
+	for header, value := range map[string]string{
+		headerSchemaCommit:       resolution.Commit,
+		headerSchemaSource:       resolution.Source,
+		headerSchemaDigest:       resolution.Digest,
+		headerSchemaModuleDigest: resolution.ModuleDigest,
+	} {
+		if value != "" {
+			w.Header().Set(header, value)
+		}
+	}
+	if resolution.Stale {
+		w.Header().Set(headerSchemaStale, "true")
 	}
 }
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"validation-service/backend/logger"
+	"validation-service/backend/service"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "validation-service/backend/proto"
+	taskv1 "validation-service/backend/proto/task/v1"
+)
+
+// tasksPath is the collection route of the task API
+const tasksPath = "/api/v1/tasks"
+
+// TaskHandler handles HTTP requests for task management
+// Request bodies use the protobuf JSON mapping of the TaskService request messages,
+// and every request message is validated with protovalidate before it reaches the service
+type TaskHandler struct {
+	taskService *service.TaskService
+	validator   protovalidate.Validator
+}
+
+// NewTaskHandler creates a new task handler
+func NewTaskHandler(taskService *service.TaskService, validator protovalidate.Validator) *TaskHandler {
+	return &TaskHandler{
+		taskService: taskService,
+		validator:   validator,
+	}
+}
+
+// Tasks handles GET (list) and POST (create) on /api/v1/tasks
+func (h *TaskHandler) Tasks(w http.ResponseWriter, r *http.Request) {
+	logger.Debug("Received request: method=%s, path=%s, remote=%s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	switch r.Method {
+	case http.MethodGet:
+		h.listTasks(w, r)
+	case http.MethodPost:
+		h.createTask(w, r)
+	default:
+		logger.Debug("Method not allowed: %s (expected GET or POST)", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Task handles GET, PATCH (update status) and DELETE on /api/v1/tasks/{id}
+func (h *TaskHandler) Task(w http.ResponseWriter, r *http.Request) {
+	logger.Debug("Received request: method=%s, path=%s, remote=%s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	id, err := url.PathUnescape(strings.TrimPrefix(r.URL.Path, tasksPath+"/"))
+	if err != nil || id == "" || strings.Contains(id, "/") {
+		logger.Debug("Invalid task path: %s", r.URL.Path)
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.getTask(w, r, id)
+	case http.MethodPatch:
+		h.updateTaskStatus(w, r, id)
+	case http.MethodDelete:
+		h.deleteTask(w, r, id)
+	default:
+		logger.Debug("Method not allowed: %s (expected GET, PATCH or DELETE)", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// createTask handles POST /api/v1/tasks with a CreateTaskRequest body
+func (h *TaskHandler) createTask(w http.ResponseWriter, r *http.Request) {
+	req := &taskv1.CreateTaskRequest{}
+	if !h.decodeRequest(w, r, req) || !h.validateRequest(w, req) {
+		return
+	}
+
+	record, err := h.taskService.CreateTask(req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	h.writeMessage(w, http.StatusCreated, record)
+}
+
+// listTasks handles GET /api/v1/tasks?pageSize=&pageToken=&status=
+func (h *TaskHandler) listTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &taskv1.ListTasksRequest{PageToken: query.Get("pageToken")}
+
+	if pageSizeStr := query.Get("pageSize"); pageSizeStr != "" {
+		pageSize, err := strconv.ParseInt(pageSizeStr, 10, 32)
+		if err != nil {
+			logger.Debug("Invalid pageSize parameter: %s", pageSizeStr)
+			http.Error(w, "Invalid pageSize: must be an integer", http.StatusBadRequest)
+			return
+		}
+		req.PageSize = int32(pageSize)
+	}
+
+	if statusStr := query.Get("status"); statusStr != "" {
+		status, err := parseTaskStatus(statusStr)
+		if err != nil {
+			logger.Debug("Invalid status parameter: %s", statusStr)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.Status = status
+	}
+
+	if !h.validateRequest(w, req) {
+		return
+	}
+
+	response, err := h.taskService.ListTasks(req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	h.writeMessage(w, http.StatusOK, response)
+}
+
+// getTask handles GET /api/v1/tasks/{id}
+func (h *TaskHandler) getTask(w http.ResponseWriter, r *http.Request, id string) {
+	req := &taskv1.GetTaskRequest{Id: id}
+	if !h.validateRequest(w, req) {
+		return
+	}
+
+	record, err := h.taskService.GetTask(req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	h.writeMessage(w, http.StatusOK, record)
+}
+
+// updateTaskStatus handles PATCH /api/v1/tasks/{id} with an UpdateTaskStatusRequest body
+// The ID in the path takes precedence over an ID in the body
+func (h *TaskHandler) updateTaskStatus(w http.ResponseWriter, r *http.Request, id string) {
+	req := &taskv1.UpdateTaskStatusRequest{}
+	if !h.decodeRequest(w, r, req) {
+		return
+	}
+	req.Id = id
+	if !h.validateRequest(w, req) {
+		return
+	}
+
+	record, err := h.taskService.UpdateTaskStatus(req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	h.writeMessage(w, http.StatusOK, record)
+}
+
+// deleteTask handles DELETE /api/v1/tasks/{id}?comment=
+func (h *TaskHandler) deleteTask(w http.ResponseWriter, r *http.Request, id string) {
+	req := &pb.DeleteTask{TaskId: id, Comment: r.URL.Query().Get("comment")}
+	if !h.validateRequest(w, req) {
+		return
+	}
+
+	record, err := h.taskService.DeleteTask(req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	h.writeMessage(w, http.StatusOK, &taskv1.DeleteTaskResponse{Task: record})
+}
+
+// decodeRequest unmarshals the JSON request body into req
+// Returns false after writing a 400 response if the body cannot be decoded
+func (h *TaskHandler) decodeRequest(w http.ResponseWriter, r *http.Request, req proto.Message) bool {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Debug("Failed to read request body: %v", err)
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return false
+	}
+	if err := protojson.Unmarshal(body, req); err != nil {
+		logger.Debug("Failed to decode request body: %v", err)
+		http.Error(w, fmt.Sprintf("Invalid JSON payload: %v", err), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// validateRequest validates req with protovalidate
+// Returns false after writing a 400 response with the violations if req is invalid
+func (h *TaskHandler) validateRequest(w http.ResponseWriter, req proto.Message) bool {
+	err := h.validator.Validate(req)
+	if err == nil {
+		return true
+	}
+
+	var validationErr *protovalidate.ValidationError
+	if !errors.As(err, &validationErr) {
+		logger.Error("Failed to validate %s: %v", req.ProtoReflect().Descriptor().FullName(), err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return false
+	}
+
+	response := ValidateProtoResponse{
+		Success: false,
+		Errors:  service.MessageValidationErrors(req, validationErr),
+	}
+	logger.Info("Rejected %s with %d violation(s)", req.ProtoReflect().Descriptor().FullName(), len(response.Errors))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error("Failed to encode response: %v", err)
+	}
+	return false
+}
+
+// writeMessage writes msg using the protobuf JSON mapping
+func (h *TaskHandler) writeMessage(w http.ResponseWriter, statusCode int, msg proto.Message) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		logger.Error("Failed to encode task response: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(data)
+}
+
+// handleError handles task service errors and returns appropriate HTTP status codes
+func (h *TaskHandler) handleError(w http.ResponseWriter, err error) {
+	errorMsg := err.Error()
+
+	switch {
+	case errors.Is(err, service.ErrInvalidArgument):
+		logger.Debug("Returning 400 Bad Request: %s", errorMsg)
+		http.Error(w, errorMsg, http.StatusBadRequest)
+	case errors.Is(err, service.ErrNotFound):
+		logger.Debug("Returning 404 Not Found: %s", errorMsg)
+		http.Error(w, errorMsg, http.StatusNotFound)
+	case errors.Is(err, service.ErrAlreadyExists):
+		logger.Debug("Returning 409 Conflict: %s", errorMsg)
+		http.Error(w, errorMsg, http.StatusConflict)
+	default:
+		logger.Error("Internal server error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// parseTaskStatus parses a task status given by enum name (e.g. "TASK_STATUS_OPEN") or number
+func parseTaskStatus(value string) (pb.TaskStatus, error) {
+	if number, ok := pb.TaskStatus_value[value]; ok {
+		return pb.TaskStatus(number), nil
+	}
+	number, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid status: must be a TaskStatus name or number")
+	}
+	return pb.TaskStatus(number), nil
+}
@@ -1,8 +1,15 @@
 package handler
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
 	"validation-service/backend/logger"
 	"validation-service/backend/service"
 )
@@ -10,28 +17,107 @@ import (
 // ValidationHandler handles HTTP requests for proto validation
 type ValidationHandler struct {
 	validationService *service.ValidationService
+	batchWorkers      int
+	maxBatchItems     int
+	maxBatchBytes     int64
 }
 
 // NewValidationHandler creates a new validation handler
-func NewValidationHandler(validationService *service.ValidationService) *ValidationHandler {
+// batchWorkers bounds how many batch items are validated concurrently
+// maxBatchItems limits the number of items accepted in a single batch request
+// maxBatchBytes limits the size of a batch request body, so it is never decoded unbounded
+func NewValidationHandler(validationService *service.ValidationService, batchWorkers, maxBatchItems int, maxBatchBytes int64) *ValidationHandler {
 	return &ValidationHandler{
 		validationService: validationService,
+		batchWorkers:      batchWorkers,
+		maxBatchItems:     maxBatchItems,
+		maxBatchBytes:     maxBatchBytes,
 	}
 }
 
 // ValidateProtoRequest represents the request payload
+// Exactly one of payload (JSON), payloadBinary (base64 wire format) or payloadText (text format) must be set
 type ValidateProtoRequest struct {
-	SchemaName string          `json:"schemaName"`
-	Payload    json.RawMessage `json:"payload"`
-	Commit     string          `json:"commit,omitempty"` // Optional commit ID, defaults to "main"
+	SchemaName    string          `json:"schemaName"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+	PayloadBinary []byte          `json:"payloadBinary,omitempty"` // Base64 encoded protobuf wire format
+	PayloadText   string          `json:"payloadText,omitempty"`   // Protobuf text format
+	Commit        string          `json:"commit,omitempty"`        // Optional commit ID, defaults to "main"
+	Strict        *bool           `json:"strict,omitempty"`        // Optional, overrides the deployment strict mode
+
+	payload []byte
+	format  service.PayloadFormat
+}
+
+// Content types accepted by POST /api/v1/validate-proto in addition to the JSON envelope
+// For these, the body is the raw payload and schemaName/commit/strict are passed as query parameters
+var payloadContentTypes = map[string]service.PayloadFormat{
+	"application/x-protobuf":      service.PayloadFormatBinary,
+	"application/protobuf":        service.PayloadFormatBinary,
+	"application/x-protobuf-text": service.PayloadFormatText,
+	"text/x-protobuf":             service.PayloadFormatText,
 }
 
 // ValidateProtoResponse represents the response payload
+// The embedded Resolution reports the commit, source and digest of the definitions used
 type ValidateProtoResponse struct {
 	Success bool                      `json:"success"`
 	Errors  []service.ValidationError `json:"errors"`
+	service.Resolution
+}
+
+// BatchValidateItem represents a single item in a batch validation request
+// schemaName, commit and strict fall back to the top-level values when omitted
+type BatchValidateItem struct {
+	SchemaName string          `json:"schemaName,omitempty"`
+	Payload    json.RawMessage `json:"payload"`
+	Commit     string          `json:"commit,omitempty"`
+	Strict     *bool           `json:"strict,omitempty"`
+}
+
+// BatchValidateRequest represents the batch request payload
+// Either items (each with its own schema) or payloads (all validated against
+// the top-level schemaName) may be provided, or both
+type BatchValidateRequest struct {
+	SchemaName string              `json:"schemaName,omitempty"`
+	Commit     string              `json:"commit,omitempty"`
+	Strict     *bool               `json:"strict,omitempty"`
+	Items      []BatchValidateItem `json:"items,omitempty"`
+	Payloads   []json.RawMessage   `json:"payloads,omitempty"`
 }
 
+// BatchValidateResponse represents the batch response payload
+type BatchValidateResponse struct {
+	Success bool                  `json:"success"` // True only if every item is valid
+	Total   int                   `json:"total"`
+	Valid   int                   `json:"valid"`
+	Invalid int                   `json:"invalid"`
+	Results []service.BatchResult `json:"results"`
+}
+
+// StreamLineResult is the NDJSON record emitted for each input line of a validation stream
+type StreamLineResult struct {
+	Line    int                       `json:"line"` // 1-based line number in the request body
+	Success bool                      `json:"success"`
+	Errors  []service.ValidationError `json:"errors"`
+	Error   string                    `json:"error,omitempty"` // Processing error (invalid JSON, etc.)
+}
+
+// StreamSummary is the final NDJSON record of a validation stream
+// The embedded Resolution reports the definitions every line was validated against
+type StreamSummary struct {
+	Summary bool   `json:"summary"` // Always true, distinguishes the summary from line records
+	Lines   int    `json:"lines"`   // Number of lines read, including blank lines
+	Total   int    `json:"total"`   // Number of payloads validated
+	Valid   int    `json:"valid"`
+	Invalid int    `json:"invalid"`
+	Error   string `json:"error,omitempty"` // Set if reading the request body failed mid-stream
+	service.Resolution
+}
+
+// streamFlushInterval is the number of line records written between flushes
+const streamFlushInterval = 64
+
 // ValidateProto handles POST /api/v1/validate-proto
 func (h *ValidationHandler) ValidateProto(w http.ResponseWriter, r *http.Request) {
 	logger.Debug("Received validation request: method=%s, path=%s, remote=%s", r.Method, r.URL.Path, r.RemoteAddr)
@@ -46,24 +132,11 @@ func (h *ValidationHandler) ValidateProto(w http.ResponseWriter, r *http.Request
 	// Set content type
 	w.Header().Set("Content-Type", "application/json")
 
-	// Parse request body
-	var req ValidateProtoRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		logger.Debug("Failed to decode request body: %v", err)
-		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
-		return
-	}
-
-	// Validate required fields
-	if req.SchemaName == "" {
-		logger.Debug("Missing required field: schemaName")
-		http.Error(w, "schemaName is required", http.StatusBadRequest)
-		return
-	}
-
-	if len(req.Payload) == 0 {
-		logger.Debug("Missing required field: payload")
-		http.Error(w, "payload is required", http.StatusBadRequest)
+	// Parse request body according to its content type
+	req, err := h.parseValidateRequest(r)
+	if err != nil {
+		logger.Debug("Invalid validation request: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -73,27 +146,22 @@ func (h *ValidationHandler) ValidateProto(w http.ResponseWriter, r *http.Request
 		commit = "main"
 	}
 
-	logger.Info("Processing validation request for schemaName=%s, commit=%s", req.SchemaName, commit)
+	logger.Info("Processing validation request for schemaName=%s, format=%s, commit=%s", req.SchemaName, req.format, commit)
 
 	// Call validation service
-	success, errors, err := h.validationService.ValidateProto(req.SchemaName, req.Payload, commit)
+	opts := service.ValidateOptions{Format: req.format, Strict: req.Strict}
+	success, errors, resolution, err := h.validationService.ValidatePayload(req.SchemaName, req.payload, commit, opts)
 	if err != nil {
 		logger.Debug("Validation service error for schemaName=%s: %v", req.SchemaName, err)
-		// Check if it's a client error (unknown schema, invalid JSON, etc.)
-		if isClientError(err) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		// Server error
-		logger.Error("Internal server error during validation: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeValidationError(w, err)
 		return
 	}
 
 	// Build response
 	response := ValidateProtoResponse{
-		Success: success,
-		Errors:  errors,
+		Success:    success,
+		Errors:     errors,
+		Resolution: resolution,
 	}
 
 	// Write response
@@ -110,9 +178,376 @@ func (h *ValidationHandler) ValidateProto(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// isClientError determines if an error is a client error (400) vs server error (500)
-func isClientError(err error) bool {
-	// Client errors: unknown schema, invalid JSON, etc.
-	// Most validation service errors are client errors
-	return true
+// parseValidateRequest reads a validation request either from the JSON envelope or,
+// for protobuf content types, from the raw body plus query parameters
+func (h *ValidationHandler) parseValidateRequest(r *http.Request) (*ValidateProtoRequest, error) {
+	var req ValidateProtoRequest
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if format, ok := payloadContentTypes[mediaType]; ok {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+
+		query := r.URL.Query()
+		req.SchemaName = query.Get("schemaName")
+		req.Commit = query.Get("commit")
+		strict, err := parseStrictParam(query.Get("strict"))
+		if err != nil {
+			return nil, err
+		}
+		req.Strict = strict
+		req.payload = body
+		req.format = format
+	} else {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			logger.Debug("Failed to decode request body: %v", err)
+			return nil, fmt.Errorf("Invalid JSON payload")
+		}
+
+		payloads := 0
+		if len(req.Payload) > 0 {
+			payloads++
+			req.payload = req.Payload
+			req.format = service.PayloadFormatJSON
+		}
+		if len(req.PayloadBinary) > 0 {
+			payloads++
+			req.payload = req.PayloadBinary
+			req.format = service.PayloadFormatBinary
+		}
+		if req.PayloadText != "" {
+			payloads++
+			req.payload = []byte(req.PayloadText)
+			req.format = service.PayloadFormatText
+		}
+		if payloads > 1 {
+			return nil, fmt.Errorf("only one of payload, payloadBinary or payloadText may be set")
+		}
+	}
+
+	// Validate required fields
+	if req.SchemaName == "" {
+		logger.Debug("Missing required field: schemaName")
+		return nil, fmt.Errorf("schemaName is required")
+	}
+
+	// An empty binary payload is a valid (all default) message, other formats need content
+	if req.payload == nil || (len(req.payload) == 0 && req.format != service.PayloadFormatBinary) {
+		logger.Debug("Missing required field: payload")
+		return nil, fmt.Errorf("payload is required")
+	}
+
+	return &req, nil
+}
+
+// ValidateBatch handles POST /api/v1/validate-proto/batch
+func (h *ValidationHandler) ValidateBatch(w http.ResponseWriter, r *http.Request) {
+	logger.Debug("Received batch validation request: method=%s, path=%s, remote=%s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	// Only allow POST method
+	if r.Method != http.MethodPost {
+		logger.Debug("Method not allowed: %s (expected POST)", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Parse request body, bounded by the configured size
+	body := r.Body
+	if h.maxBatchBytes > 0 {
+		body = http.MaxBytesReader(w, r.Body, h.maxBatchBytes)
+	}
+	var req BatchValidateRequest
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			logger.Debug("Batch request body exceeds %d bytes", h.maxBatchBytes)
+			http.Error(w, fmt.Sprintf("batch request body exceeds %d bytes", h.maxBatchBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+		logger.Debug("Failed to decode batch request body: %v", err)
+		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+		return
+	}
+
+	// Flatten items and payloads into service batch items
+	items, err := h.buildBatchItems(req)
+	if err != nil {
+		logger.Debug("Invalid batch request: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	logger.Info("Processing batch validation request with %d item(s)", len(items))
+
+	// Call validation service
+	results := h.validationService.ValidateBatch(items, h.batchWorkers)
+
+	// Build response
+	response := BatchValidateResponse{
+		Success: true,
+		Total:   len(results),
+		Results: results,
+	}
+	for _, result := range results {
+		if result.Success {
+			response.Valid++
+		} else {
+			response.Invalid++
+			response.Success = false
+		}
+	}
+
+	// Write response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error("Failed to encode batch response: %v", err)
+		return
+	}
+
+	logger.Info("Batch validation completed: total=%d, valid=%d, invalid=%d", response.Total, response.Valid, response.Invalid)
+}
+
+// ValidateStream handles POST /api/v1/validate-proto/stream?schemaName={name}&commit={commit}
+// The request body is newline-delimited JSON (one payload per line). Each line is validated
+// as it is read and a per-line NDJSON record is streamed back, followed by a summary record.
+// The descriptor is resolved once for the whole stream.
+func (h *ValidationHandler) ValidateStream(w http.ResponseWriter, r *http.Request) {
+	logger.Debug("Received stream validation request: method=%s, path=%s, remote=%s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	// Only allow POST method
+	if r.Method != http.MethodPost {
+		logger.Debug("Method not allowed: %s (expected POST)", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+	schemaName := query.Get("schemaName")
+	if schemaName == "" {
+		logger.Debug("Missing required query parameter: schemaName")
+		http.Error(w, "schemaName is required", http.StatusBadRequest)
+		return
+	}
+	commit := query.Get("commit")
+	strict, err := parseStrictParam(query.Get("strict"))
+	if err != nil {
+		logger.Debug("Invalid strict query parameter: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	opts := service.ValidateOptions{Format: service.PayloadFormatJSON, Strict: strict}
+
+	// Resolve the descriptor once for the whole stream
+	resolved, err := h.validationService.ResolveMessageDescriptor(schemaName, commit)
+	if err != nil {
+		logger.Debug("Failed to resolve descriptor for stream schemaName=%s: %v", schemaName, err)
+		writeValidationError(w, err)
+		return
+	}
+
+	logger.Info("Processing stream validation request for schemaName=%s, commit=%s, source=%s", schemaName, commit, resolved.Source)
+
+	// Allow reading the request body while the response is being written
+	controller := http.NewResponseController(w)
+	if err := controller.EnableFullDuplex(); err != nil {
+		logger.Debug("Full duplex not supported, continuing without it: %v", err)
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	out := bufio.NewWriter(w)
+	encoder := json.NewEncoder(out)
+	flush := func() {
+		if err := out.Flush(); err != nil {
+			return
+		}
+		if err := controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			logger.Debug("Failed to flush stream response: %v", err)
+		}
+	}
+
+	summary := StreamSummary{Summary: true, Resolution: resolved.Resolution()}
+	reader := bufio.NewReader(r.Body)
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if len(line) > 0 || readErr == nil {
+			summary.Lines++
+		}
+
+		if payload := bytes.TrimSpace(line); len(payload) > 0 {
+			result := StreamLineResult{Line: summary.Lines}
+			success, validationErrors, err := h.validationService.ValidateMessage(resolved, payload, opts)
+			if err != nil {
+				result.Errors = []service.ValidationError{}
+				result.Error = err.Error()
+			} else {
+				result.Success = success
+				result.Errors = validationErrors
+			}
+
+			summary.Total++
+			if result.Success {
+				summary.Valid++
+			} else {
+				summary.Invalid++
+			}
+
+			if err := encoder.Encode(result); err != nil {
+				logger.Debug("Client went away during stream for schemaName=%s: %v", schemaName, err)
+				return
+			}
+			// Flush periodically, and whenever the next read may block on the client
+			if summary.Total%streamFlushInterval == 0 || reader.Buffered() == 0 {
+				flush()
+			}
+		}
+
+		if readErr != nil {
+			if readErr != io.EOF {
+				logger.Error("Failed to read stream body for schemaName=%s: %v", schemaName, readErr)
+				summary.Error = fmt.Sprintf("failed to read request body: %v", readErr)
+			}
+			break
+		}
+	}
+
+	if err := encoder.Encode(summary); err != nil {
+		logger.Debug("Failed to write stream summary for schemaName=%s: %v", schemaName, err)
+		return
+	}
+	flush()
+
+	logger.Info("Stream validation completed for schemaName=%s: lines=%d, total=%d, valid=%d, invalid=%d", schemaName, summary.Lines, summary.Total, summary.Valid, summary.Invalid)
+}
+
+// buildBatchItems converts a batch request into service batch items, applying
+// top-level defaults and enforcing the configured batch size limit
+func (h *ValidationHandler) buildBatchItems(req BatchValidateRequest) ([]service.BatchItem, error) {
+	total := len(req.Items) + len(req.Payloads)
+	if total == 0 {
+		return nil, fmt.Errorf("items or payloads is required")
+	}
+	if h.maxBatchItems > 0 && total > h.maxBatchItems {
+		return nil, fmt.Errorf("batch contains %d items, maximum is %d", total, h.maxBatchItems)
+	}
+	if len(req.Payloads) > 0 && req.SchemaName == "" {
+		return nil, fmt.Errorf("schemaName is required when payloads is provided")
+	}
+
+	items := make([]service.BatchItem, 0, total)
+	for i, item := range req.Items {
+		schemaName := item.SchemaName
+		if schemaName == "" {
+			schemaName = req.SchemaName
+		}
+		if schemaName == "" {
+			return nil, fmt.Errorf("items[%d]: schemaName is required", i)
+		}
+		if len(item.Payload) == 0 {
+			return nil, fmt.Errorf("items[%d]: payload is required", i)
+		}
+		commit := item.Commit
+		if commit == "" {
+			commit = req.Commit
+		}
+		strict := item.Strict
+		if strict == nil {
+			strict = req.Strict
+		}
+		items = append(items, service.BatchItem{
+			SchemaName: schemaName,
+			Payload:    item.Payload,
+			Commit:     commit,
+			Options:    service.ValidateOptions{Format: service.PayloadFormatJSON, Strict: strict},
+		})
+	}
+	for _, payload := range req.Payloads {
+		items = append(items, service.BatchItem{
+			SchemaName: req.SchemaName,
+			Payload:    payload,
+			Commit:     req.Commit,
+			Options:    service.ValidateOptions{Format: service.PayloadFormatJSON, Strict: req.Strict},
+		})
+	}
+
+	return items, nil
+}
+
+// parseStrictParam parses the optional strict query parameter
+// Returns nil when the parameter is absent so the deployment default applies
+func parseStrictParam(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	strict, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid strict parameter %q: must be true or false", value)
+	}
+	return &strict, nil
+}
+
+// GetCacheStats handles GET /api/v1/descriptor-cache/stats
+func (h *ValidationHandler) GetCacheStats(w http.ResponseWriter, r *http.Request) {
+	logger.Debug("Received cache stats request: method=%s, path=%s, remote=%s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		logger.Debug("Method not allowed: %s (expected GET)", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	stats := h.validationService.CacheStats()
+
+	// Set response headers
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	// Encode and send JSON response
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		logger.Error("Failed to encode cache stats response: %v", err)
+		return
+	}
+
+	logger.Debug("Returned descriptor cache stats: hits=%d, misses=%d, entries=%d", stats.Hits, stats.Misses, stats.Entries)
+}
+
+// writeValidationError writes the HTTP error for a failure of the validation service
+// An unknown schema is a bad request to the validation endpoints, as it always was;
+// every other error is mapped by writeServiceError
+func writeValidationError(w http.ResponseWriter, err error) {
+	if errors.Is(err, service.ErrNotFound) {
+		logger.Debug("Returning 400 Bad Request: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeServiceError(w, err)
+}
+
+// writeServiceError writes the HTTP error for a failure of the validation or schema service
+// Both services resolve through the same sources, so every endpoint maps errors alike:
+// - 400 for invalid requests and versions the sources cannot serve
+// - 404 for messages and schemas no source has, see writeValidationError
+// - 502 for failures of a source, e.g. BSR being unreachable
+// - 500 for anything else, without exposing the error
+func writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidArgument) || errors.Is(err, service.ErrVersionUnavailable):
+		logger.Debug("Returning 400 Bad Request: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrNotFound):
+		logger.Debug("Returning 404 Not Found: %v", err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrSourceUnavailable):
+		logger.Error("Source failure: %v", err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+	default:
+		logger.Error("Internal server error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
 }
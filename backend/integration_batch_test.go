@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// batchValidateResponse represents the response from the batch validation API
+type batchValidateResponse struct {
+	Success bool `json:"success"`
+	Total   int  `json:"total"`
+	Valid   int  `json:"valid"`
+	Invalid int  `json:"invalid"`
+	Results []struct {
+		Index   int    `json:"index"`
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+		Errors  []struct {
+			Friendly  string `json:"friendly"`
+			Technical string `json:"technical"`
+		} `json:"errors"`
+	} `json:"results"`
+}
+
+// callBatchValidateAPI makes a POST request to the batch validation endpoint
+func callBatchValidateAPI(t *testing.T, baseURL string, body interface{}) (*batchValidateResponse, int) {
+	reqBytes, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	resp, err := http.Post(baseURL+"/api/v1/validate-proto/batch", "application/json", bytes.NewBuffer(reqBytes))
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result batchValidateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+func TestBatchValidationAPI(t *testing.T) {
+	baseURL := startTestServer(t)
+
+	t.Run("mixed items", func(t *testing.T) {
+		result, statusCode := callBatchValidateAPI(t, baseURL, map[string]interface{}{
+			"items": []map[string]interface{}{
+				{"schemaName": "proto.HelloRequest", "payload": map[string]interface{}{"name": "John"}},
+				{"schemaName": "proto.HelloRequest", "payload": map[string]interface{}{"name": "Jo"}},
+				{"schemaName": "proto.DeleteTask", "payload": map[string]interface{}{"taskId": "task-1"}},
+				{"schemaName": "proto.DoesNotExist", "payload": map[string]interface{}{}},
+			},
+		})
+		if statusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", statusCode)
+		}
+
+		if result.Success || result.Total != 4 || result.Valid != 2 || result.Invalid != 2 {
+			t.Errorf("Unexpected summary: success=%v, total=%d, valid=%d, invalid=%d", result.Success, result.Total, result.Valid, result.Invalid)
+		}
+
+		wantSuccess := []bool{true, false, true, false}
+		for i, r := range result.Results {
+			if r.Index != i {
+				t.Errorf("Result %d: expected index %d, got %d", i, i, r.Index)
+			}
+			if r.Success != wantSuccess[i] {
+				t.Errorf("Result %d: expected success=%v, got %v (errors: %v, error: %s)", i, wantSuccess[i], r.Success, r.Errors, r.Error)
+			}
+		}
+		if len(result.Results[1].Errors) != 1 {
+			t.Errorf("Expected 1 validation error for item 1, got %d", len(result.Results[1].Errors))
+		}
+		if result.Results[3].Error == "" {
+			t.Errorf("Expected processing error for unknown schema in item 3")
+		}
+	})
+
+	t.Run("one schema with many payloads", func(t *testing.T) {
+		payloads := make([]map[string]interface{}, 0, 50)
+		for i := 0; i < 50; i++ {
+			name := "Valid name"
+			if i%10 == 0 {
+				name = "x"
+			}
+			payloads = append(payloads, map[string]interface{}{"name": name})
+		}
+
+		result, statusCode := callBatchValidateAPI(t, baseURL, map[string]interface{}{
+			"schemaName": "proto.HelloRequest",
+			"payloads":   payloads,
+		})
+		if statusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", statusCode)
+		}
+
+		if result.Total != 50 || result.Valid != 45 || result.Invalid != 5 {
+			t.Errorf("Unexpected summary: total=%d, valid=%d, invalid=%d", result.Total, result.Valid, result.Invalid)
+		}
+		for i, r := range result.Results {
+			if r.Index != i {
+				t.Fatalf("Result %d: expected index %d, got %d", i, i, r.Index)
+			}
+			if r.Success != (i%10 != 0) {
+				t.Errorf("Result %d: unexpected success=%v", i, r.Success)
+			}
+		}
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		tests := []struct {
+			name string
+			body interface{}
+		}{
+			{name: "empty batch", body: map[string]interface{}{}},
+			{name: "payloads without schemaName", body: map[string]interface{}{"payloads": []interface{}{map[string]interface{}{}}}},
+			{name: "item without payload", body: map[string]interface{}{"items": []interface{}{map[string]interface{}{"schemaName": "proto.HelloRequest"}}}},
+			{name: "too many items", body: map[string]interface{}{"schemaName": "proto.HelloRequest", "payloads": make([]map[string]interface{}, 101)}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, statusCode := callBatchValidateAPI(t, baseURL, tt.body)
+				if statusCode != http.StatusBadRequest {
+					t.Errorf("Expected status 400, got %d", statusCode)
+				}
+			})
+		}
+	})
+
+	t.Run("rejects oversized bodies", func(t *testing.T) {
+		// The test server limits batch bodies to 1 MiB
+		_, statusCode := callBatchValidateAPI(t, baseURL, map[string]interface{}{
+			"schemaName": "proto.HelloRequest",
+			"payloads":   []map[string]interface{}{{"name": strings.Repeat("x", 2<<20)}},
+		})
+		if statusCode != http.StatusRequestEntityTooLarge {
+			t.Errorf("Expected status 413, got %d", statusCode)
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"validation-service/backend/proto"
+	"validation-service/backend/proto/protoconnect"
+	taskv1 "validation-service/backend/proto/task/v1"
+	"validation-service/backend/proto/task/v1/taskv1connect"
+	validationv1 "validation-service/backend/proto/validation/v1"
+	"validation-service/backend/proto/validation/v1/validationv1connect"
+)
+
+func TestConnectProtocols(t *testing.T) {
+	baseURL := startTestConnectServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	protocols := []struct {
+		name string
+		opts []connect.ClientOption
+	}{
+		{name: "connect", opts: []connect.ClientOption{connect.WithProtoJSON()}},
+		{name: "grpc-web", opts: []connect.ClientOption{connect.WithGRPCWeb()}},
+	}
+
+	for _, protocol := range protocols {
+		t.Run(protocol.name, func(t *testing.T) {
+			greeting := protoconnect.NewGreetingServiceClient(http.DefaultClient, baseURL, protocol.opts...)
+
+			resp, err := greeting.SayHello(ctx, connect.NewRequest(&proto.HelloRequest{Name: "John"}))
+			if err != nil {
+				t.Fatalf("SayHello failed: %v", err)
+			}
+			if resp.Msg.GetMessage() != "hello John" {
+				t.Errorf("Unexpected greeting %q", resp.Msg.GetMessage())
+			}
+
+			// Invalid requests are rejected by the validation interceptor with the same details as gRPC
+			_, err = greeting.SayHello(ctx, connect.NewRequest(&proto.HelloRequest{Name: "Jo"}))
+			var connectErr *connect.Error
+			if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeInvalidArgument {
+				t.Fatalf("Expected InvalidArgument, got %v", err)
+			}
+			var badRequest *errdetails.BadRequest
+			for _, detail := range connectErr.Details() {
+				if msg, err := detail.Value(); err == nil {
+					if br, ok := msg.(*errdetails.BadRequest); ok {
+						badRequest = br
+					}
+				}
+			}
+			if badRequest == nil || len(badRequest.GetFieldViolations()) != 1 || badRequest.GetFieldViolations()[0].GetField() != "name" {
+				t.Errorf("Expected BadRequest detail for field name, got %v", connectErr.Details())
+			}
+
+			validation := validationv1connect.NewValidationServiceClient(http.DefaultClient, baseURL, protocol.opts...)
+			validated, err := validation.ValidateProto(ctx, connect.NewRequest(&validationv1.ValidateProtoRequest{
+				SchemaName: "proto.HelloRequest",
+				Payload:    &validationv1.ValidateProtoRequest_PayloadJson{PayloadJson: `{"name": "Jo"}`},
+			}))
+			if err != nil {
+				t.Fatalf("ValidateProto failed: %v", err)
+			}
+			if validated.Msg.GetSuccess() || len(validated.Msg.GetErrors()) != 1 {
+				t.Errorf("Expected 1 validation error, got %v", validated.Msg)
+			}
+
+			tasks := taskv1connect.NewTaskServiceClient(http.DefaultClient, baseURL, protocol.opts...)
+			created, err := tasks.CreateTask(ctx, connect.NewRequest(&taskv1.CreateTaskRequest{
+				Task: &proto.Task{Name: "Ship it", Timestamp: timestamppb.Now(), Status: proto.TaskStatus_TASK_STATUS_OPEN},
+			}))
+			if err != nil {
+				t.Fatalf("CreateTask failed: %v", err)
+			}
+			if _, err := tasks.GetTask(ctx, connect.NewRequest(&taskv1.GetTaskRequest{Id: "does-not-exist"})); connect.CodeOf(err) != connect.CodeNotFound {
+				t.Errorf("Expected NotFound for unknown task, got %v", err)
+			}
+			if _, err := tasks.DeleteTask(ctx, connect.NewRequest(&proto.DeleteTask{TaskId: created.Msg.GetId()})); err != nil {
+				t.Errorf("DeleteTask failed: %v", err)
+			}
+		})
+	}
+
+	t.Run("grpc", func(t *testing.T) {
+		// Native gRPC clients reach the HTTP port over cleartext HTTP/2
+		conn, err := grpc.NewClient(strings.TrimPrefix(baseURL, "http://"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatalf("Failed to create gRPC client: %v", err)
+		}
+		defer conn.Close()
+
+		greeting := proto.NewGreetingServiceClient(conn)
+		resp, err := greeting.SayHello(ctx, &proto.HelloRequest{Name: "John"})
+		if err != nil {
+			t.Fatalf("SayHello failed: %v", err)
+		}
+		if resp.GetMessage() != "hello John" {
+			t.Errorf("Unexpected greeting %q", resp.GetMessage())
+		}
+		if _, err := greeting.SayHello(ctx, &proto.HelloRequest{Name: "Jo"}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("plain JSON POST", func(t *testing.T) {
+		// This is what a browser fetch against the Connect protocol looks like
+		resp, err := http.Post(baseURL+protoconnect.GreetingServiceSayHelloProcedure, "application/json", bytes.NewBufferString(`{"name": "World"}`))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		var body bytes.Buffer
+		body.ReadFrom(resp.Body)
+		if resp.StatusCode != http.StatusOK || !strings.Contains(body.String(), "hello World") {
+			t.Errorf("Expected greeting, got %d: %s", resp.StatusCode, body.String())
+		}
+		if resp.Header.Get("Access-Control-Allow-Origin") != "*" {
+			t.Errorf("Expected CORS headers on Connect response")
+		}
+	})
+
+	t.Run("CORS preflight", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodOptions, baseURL+protoconnect.GreetingServiceSayHelloProcedure, nil)
+		req.Header.Set("Origin", "http://localhost:5173")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "connect-protocol-version, content-type")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusNoContent {
+			t.Errorf("Expected 204, got %d", resp.StatusCode)
+		}
+		for _, header := range []string{"Connect-Protocol-Version", "X-Grpc-Web"} {
+			if !strings.Contains(resp.Header.Get("Access-Control-Allow-Headers"), header) {
+				t.Errorf("Expected %s to be allowed, got %q", header, resp.Header.Get("Access-Control-Allow-Headers"))
+			}
+		}
+		if !strings.Contains(resp.Header.Get("Access-Control-Expose-Headers"), "Grpc-Status") {
+			t.Errorf("Expected gRPC-Web trailers to be exposed, got %q", resp.Header.Get("Access-Control-Expose-Headers"))
+		}
+	})
+}
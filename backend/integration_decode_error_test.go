@@ -0,0 +1,126 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDecodeErrorsValidationAPI(t *testing.T) {
+	baseURL := startTestServer(t)
+
+	validOrder := func(item map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"customer": map[string]interface{}{"email": "jane@example.com", "phone": "+1234567890", "name": "Jane", "address": "1 Market Street"},
+			"items":    []interface{}{item},
+			"shipping": map[string]interface{}{"type": "SHIPPING_TYPE_DIGITAL"},
+			"total":    9.99,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		schemaName string
+		payload    interface{}
+		want       []structuredError
+	}{
+		{
+			name:       "string for enum field",
+			schemaName: "proto.Task",
+			payload:    map[string]interface{}{"name": "Task", "timestamp": "2024-01-15T10:30:00Z", "status": true},
+			want:       []structuredError{{Field: "status", JSONField: "status", Pointer: "/status", RuleID: "json.type_mismatch", Value: true}},
+		},
+		{
+			name:       "malformed timestamp",
+			schemaName: "proto.Task",
+			payload:    map[string]interface{}{"name": "Task", "timestamp": "yesterday", "status": "TASK_STATUS_OPEN"},
+			want:       []structuredError{{Field: "timestamp", JSONField: "timestamp", Pointer: "/timestamp", RuleID: "json.invalid_format", Value: "yesterday"}},
+		},
+		{
+			name:       "nested integer as word",
+			schemaName: "proto.ComplexOrder",
+			payload:    validOrder(map[string]interface{}{"productId": "sku-1", "quantity": "three", "price": 9.99}),
+			want:       []structuredError{{Field: "items[0].quantity", JSONField: "items[0].quantity", Pointer: "/items/0/quantity", RuleID: "json.type_mismatch", Value: "three"}},
+		},
+		{
+			name:       "integer out of range",
+			schemaName: "proto.SimpleUser",
+			payload:    map[string]interface{}{"name": "John Doe", "email": "john@example.com", "age": 5000000000},
+			want:       []structuredError{{Field: "age", JSONField: "age", Pointer: "/age", RuleID: "json.out_of_range", Value: float64(5000000000)}},
+		},
+		{
+			name:       "every bad value is reported",
+			schemaName: "proto.Task",
+			payload:    map[string]interface{}{"name": 7, "timestamp": "yesterday", "status": "TASK_STATUS_OPEN"},
+			want: []structuredError{
+				{Field: "name", JSONField: "name", Pointer: "/name", RuleID: "json.type_mismatch", Value: float64(7)},
+				{Field: "timestamp", JSONField: "timestamp", Pointer: "/timestamp", RuleID: "json.invalid_format", Value: "yesterday"},
+			},
+		},
+		{
+			name:       "undefined enum name",
+			schemaName: "proto.Task",
+			payload:    map[string]interface{}{"name": "Task", "timestamp": "2024-01-15T10:30:00Z", "status": "TASK_STATUS_BOGUS"},
+			want:       []structuredError{{Field: "status", JSONField: "status", Pointer: "/status", RuleID: "json.invalid_enum", Value: "TASK_STATUS_BOGUS"}},
+		},
+		{
+			name:       "object instead of message",
+			schemaName: "proto.ComplexOrder",
+			payload:    map[string]interface{}{"customer": "Jane"},
+			want:       []structuredError{{Field: "customer", JSONField: "customer", Pointer: "/customer", RuleID: "json.type_mismatch", Value: "Jane"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := callValidateAPIStructured(t, baseURL, tt.schemaName, tt.payload)
+			if len(errors) != len(tt.want) {
+				t.Fatalf("Expected %d error(s), got %d: %+v", len(tt.want), len(errors), errors)
+			}
+
+			for i, want := range tt.want {
+				got := errors[i]
+				if got.Friendly == "" || got.Technical == "" {
+					t.Errorf("Error %d: expected friendly and technical messages, got %+v", i, got)
+				}
+				if !strings.Contains(got.Friendly, want.JSONField) {
+					t.Errorf("Error %d: expected friendly message to name %s, got %q", i, want.JSONField, got.Friendly)
+				}
+				if got.Field != want.Field || got.JSONField != want.JSONField || got.Pointer != want.Pointer || got.RuleID != want.RuleID || got.Value != want.Value {
+					t.Errorf("Error %d: expected %+v, got %+v", i, want, got)
+				}
+			}
+		})
+	}
+
+	t.Run("undefined enum name in strict mode", func(t *testing.T) {
+		payload := map[string]interface{}{"name": "Task", "timestamp": "2024-01-15T10:30:00Z", "status": "TASK_STATUS_BOGUS"}
+		success, errors := callValidateAPIStrict(t, baseURL, "proto.Task", payload, true)
+		if success || len(errors) != 1 {
+			t.Fatalf("Expected 1 error, got success=%v, errors=%+v", success, errors)
+		}
+		if got := errors[0]; got.RuleID != "json.invalid_enum" || got.Pointer != "/status" {
+			t.Errorf("Unexpected enum error: %+v", got)
+		}
+	})
+
+	t.Run("malformed JSON is still a processing error", func(t *testing.T) {
+		result, statusCode := postValidate(t, baseURL+"/api/v1/validate-proto", "application/json", []byte(`{"schemaName": "proto.HelloRequest", "payload": {"name": }`))
+		if statusCode != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d (%+v)", statusCode, result)
+		}
+	})
+
+	t.Run("batch reports decode errors per item", func(t *testing.T) {
+		result, statusCode := callBatchValidateAPI(t, baseURL, map[string]interface{}{
+			"schemaName": "proto.HelloRequest",
+			"payloads":   []interface{}{map[string]interface{}{"name": 12345}},
+		})
+		if statusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", statusCode)
+		}
+		if r := result.Results[0]; r.Success || r.Error != "" || len(r.Errors) != 1 {
+			t.Errorf("Expected 1 structured error, got %+v", r)
+		}
+	})
+}
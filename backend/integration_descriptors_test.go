@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"validation-service/backend/config"
+	"validation-service/backend/handler"
+	"validation-service/backend/logger"
+	"validation-service/backend/service"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"buf.build/go/protovalidate"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// startTestDescriptorsServer serves the descriptors, validation, schema and proto files APIs
+// over the compiled-in types and a registry persisted to dir, with registration enabled,
+// and returns the base URL
+func startTestDescriptorsServer(t *testing.T, dir string) string {
+	return startTestDescriptorsServerWith(t, dir, true)
+}
+
+// startTestDescriptorsServerWith is startTestDescriptorsServer with registration enabled
+// or not
+func startTestDescriptorsServerWith(t *testing.T, dir string, registrationEnabled bool) string {
+	logger.Init()
+	validator, err := protovalidate.New()
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+	registry, err := service.NewDescriptorRegistry(dir)
+	if err != nil {
+		t.Fatalf("Failed to create descriptor registry: %v", err)
+	}
+
+	local := service.NewRegistryDescriptorSource(nil)
+	descriptors := service.NewDescriptorSourceForMode(config.LocalOnly, service.DescriptorSources{Local: local, Registered: registry})
+	schemas := service.NewSchemaSourceForMode(config.LocalOnly, service.SchemaSources{
+		Local:      service.NewLocalSchemaSource(filepath.Join("gen", "jsonschema")),
+		Registered: service.NewGeneratedSchemaSource(registry),
+	}, nil)
+	validationHandler := handler.NewValidationHandler(service.NewValidationService(validator, descriptors, false), 4, 100, 1<<20)
+	schemaHandler := handler.NewSchemaHandler(service.NewSchemaService(schemas, local, registry))
+	descriptorsHandler := handler.NewDescriptorsHandler(registry, registrationEnabled)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/validate-proto", validationHandler.ValidateProto)
+	mux.HandleFunc("/api/v1/schema/", schemaHandler.GetSchema)
+	mux.HandleFunc("/api/v1/proto-files", schemaHandler.ListProtoFiles)
+	mux.HandleFunc("/api/v1/descriptors", descriptorsHandler.Descriptors)
+	mux.HandleFunc("/api/v1/descriptors/", descriptorsHandler.Descriptor)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// shipmentDescriptorSet returns a FileDescriptorSet of shipping.Shipment, whose tracking
+// number must have at least minLen characters
+func shipmentDescriptorSet(minLen uint64) *descriptorpb.FileDescriptorSet {
+	options := &descriptorpb.FieldOptions{}
+	proto.SetExtension(options, validate.E_Field, &validate.FieldRules{
+		Type: &validate.FieldRules_String_{String_: &validate.StringRules{MinLen: proto.Uint64(minLen)}},
+	})
+	return &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:       proto.String("shipping/shipment.proto"),
+		Package:    proto.String("shipping"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"buf/validate/validate.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Shipment"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("tracking_number"),
+				JsonName: proto.String("trackingNumber"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Options:  options,
+			}},
+		}},
+	}}}
+}
+
+// registerDescriptors posts set under version in the encoding of contentType
+func registerDescriptors(t *testing.T, baseURL, version, contentType string, set *descriptorpb.FileDescriptorSet) *http.Response {
+	t.Helper()
+	var body []byte
+	var err error
+	if contentType == "application/json" {
+		body, err = protojson.Marshal(set)
+	} else {
+		body, err = proto.Marshal(set)
+	}
+	if err != nil {
+		t.Fatalf("Failed to encode descriptor set: %v", err)
+	}
+	resp, err := http.Post(baseURL+"/api/v1/descriptors?version="+version, contentType, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to register descriptors: %v", err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+// validateShipment validates a shipment against version and returns the status and response
+func validateShipment(t *testing.T, baseURL, version, trackingNumber string) (int, map[string]interface{}) {
+	t.Helper()
+	body := `{"schemaName": "shipping.Shipment", "commit": "` + version + `", "payload": {"trackingNumber": "` + trackingNumber + `"}}`
+	resp, err := http.Post(baseURL+"/api/v1/validate-proto", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to validate: %v", err)
+	}
+	defer resp.Body.Close()
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+func TestDescriptorRegistrationAPI(t *testing.T) {
+	dir := t.TempDir()
+	baseURL := startTestDescriptorsServer(t, dir)
+
+	if resp := registerDescriptors(t, baseURL, "shipping-v1", "application/x-protobuf", shipmentDescriptorSet(5)); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Expected 201 registering a binary set, got %d", resp.StatusCode)
+	}
+	if resp := registerDescriptors(t, baseURL, "shipping-v2", "application/json", shipmentDescriptorSet(10)); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Expected 201 registering a JSON set, got %d", resp.StatusCode)
+	}
+
+	// Each version validates with its own rules
+	status, result := validateShipment(t, baseURL, "registered/shipping-v1", "1Z999")
+	if status != http.StatusOK || result["success"] != true || result["source"] != "registered" || result["resolvedCommit"] != "registered/shipping-v1" {
+		t.Errorf("Expected shipping-v1 to accept a 5 character tracking number, got %d %v", status, result)
+	}
+	if status, result := validateShipment(t, baseURL, "registered/shipping-v2", "1Z999"); status != http.StatusOK || result["success"] != false {
+		t.Errorf("Expected shipping-v2 to reject a 5 character tracking number, got %d %v", status, result)
+	}
+	// Registered versions are only requested with their prefix, the plain name is left to
+	// the other sources
+	if status, _ := validateShipment(t, baseURL, "shipping-v1", "1Z999"); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a registered version without its prefix, got %d", status)
+	}
+
+	// The schema is generated from the registered definitions
+	resp, err := http.Get(baseURL + "/api/v1/schema/shipping.Shipment?commit=registered/shipping-v2&bundle=false")
+	if err != nil {
+		t.Fatalf("Failed to get schema: %v", err)
+	}
+	var schema map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&schema)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("X-Schema-Commit") != "registered/shipping-v2" {
+		t.Fatalf("Expected the schema of shipping-v2, got %d (commit %q)", resp.StatusCode, resp.Header.Get("X-Schema-Commit"))
+	}
+	trackingNumber := schema["properties"].(map[string]interface{})["tracking_number"].(map[string]interface{})
+	if trackingNumber["minLength"] != float64(10) {
+		t.Errorf("Expected minLength 10, got %v", trackingNumber["minLength"])
+	}
+
+	// The proto files list has the registered messages next to the compiled-in ones
+	resp, err = http.Get(baseURL + "/api/v1/proto-files")
+	if err != nil {
+		t.Fatalf("Failed to list proto files: %v", err)
+	}
+	var protoFiles []service.ProtoFile
+	json.NewDecoder(resp.Body).Decode(&protoFiles)
+	resp.Body.Close()
+	listed := make(map[string]bool)
+	for _, protoFile := range protoFiles {
+		listed[protoFile.Version+"/"+protoFile.FullyQualifiedName] = true
+	}
+	for _, key := range []string{"/proto.SimpleUser", "registered/shipping-v1/shipping.Shipment", "registered/shipping-v2/shipping.Shipment"} {
+		if !listed[key] {
+			t.Errorf("Expected %s to be listed, got %+v", key, protoFiles)
+		}
+	}
+	if listed["registered/shipping-v1/buf.validate.FieldRules"] {
+		t.Error("Expected the buf/validate imports not to be listed")
+	}
+
+	// Registrations survive a restart
+	restartedURL := startTestDescriptorsServer(t, dir)
+	if status, result := validateShipment(t, restartedURL, "registered/shipping-v1", "1Z999"); status != http.StatusOK || result["success"] != true {
+		t.Errorf("Expected shipping-v1 to be reloaded, got %d %v", status, result)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, restartedURL+"/api/v1/descriptors/shipping-v1", nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to delete descriptors: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected 204 deleting a version, got %d", resp.StatusCode)
+	}
+	if status, _ := validateShipment(t, restartedURL, "registered/shipping-v1", "1Z999"); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a deleted version, got %d", status)
+	}
+
+	resp, err = http.Get(restartedURL + "/api/v1/descriptors")
+	if err != nil {
+		t.Fatalf("Failed to list descriptors: %v", err)
+	}
+	var versions []service.RegisteredVersion
+	json.NewDecoder(resp.Body).Decode(&versions)
+	resp.Body.Close()
+	if len(versions) != 1 || versions[0].Version != "shipping-v2" || versions[0].Ref != "registered/shipping-v2" || versions[0].Messages[0] != "shipping.Shipment" {
+		t.Errorf("Expected only shipping-v2 to be registered, got %+v", versions)
+	}
+}
+
+func TestDescriptorRegistrationRejectsInvalidSets(t *testing.T) {
+	baseURL := startTestDescriptorsServer(t, t.TempDir())
+
+	unlinked := shipmentDescriptorSet(5)
+	unlinked.File[0].Dependency = append(unlinked.File[0].Dependency, "shipping/address.proto")
+	if resp := registerDescriptors(t, baseURL, "shipping-v1", "application/x-protobuf", unlinked); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a set that does not link, got %d", resp.StatusCode)
+	}
+	if resp := registerDescriptors(t, baseURL, "main", "application/x-protobuf", shipmentDescriptorSet(5)); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a reserved version, got %d", resp.StatusCode)
+	}
+	if resp := registerDescriptors(t, baseURL, "0123456789abcdef0123456789abcdef", "application/x-protobuf", shipmentDescriptorSet(5)); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a commit ID version, got %d", resp.StatusCode)
+	}
+	if resp := registerDescriptors(t, baseURL, "shipping-v1", "text/plain", shipmentDescriptorSet(5)); resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected 415 for an unsupported content type, got %d", resp.StatusCode)
+	}
+
+	resp, err := http.Get(baseURL + "/api/v1/descriptors/shipping-v1")
+	if err != nil {
+		t.Fatalf("Failed to get descriptors: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for a version that was never registered, got %d", resp.StatusCode)
+	}
+}
+
+func TestDescriptorRegistrationDisabled(t *testing.T) {
+	baseURL := startTestDescriptorsServerWith(t, t.TempDir(), false)
+
+	if resp := registerDescriptors(t, baseURL, "shipping-v1", "application/x-protobuf", shipmentDescriptorSet(5)); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 registering with registration disabled, got %d", resp.StatusCode)
+	}
+	req, _ := http.NewRequest(http.MethodDelete, baseURL+"/api/v1/descriptors/shipping-v1", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to delete descriptors: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 deleting with registration disabled, got %d", resp.StatusCode)
+	}
+
+	// Reading stays available
+	resp, err = http.Get(baseURL + "/api/v1/descriptors")
+	if err != nil {
+		t.Fatalf("Failed to list descriptors: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 listing descriptors, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"validation-service/backend/proto"
+	validationv1 "validation-service/backend/proto/validation/v1"
+)
+
+// validationDetails extracts the validation details attached to a gRPC status error
+func validationDetails(t *testing.T, err error) (*validate.Violations, *errdetails.BadRequest) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument status, got %v", err)
+	}
+
+	var violations *validate.Violations
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *validate.Violations:
+			violations = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+	if violations == nil || badRequest == nil {
+		t.Fatalf("Expected buf.validate.Violations and google.rpc.BadRequest details, got %v", st.Details())
+	}
+	return violations, badRequest
+}
+
+func TestGRPCValidationErrorDetails(t *testing.T) {
+	conn := startTestGRPCServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	t.Run("SayHello", func(t *testing.T) {
+		_, err := proto.NewGreetingServiceClient(conn).SayHello(ctx, &proto.HelloRequest{Name: "Jo"})
+		violations, badRequest := validationDetails(t, err)
+
+		if len(violations.GetViolations()) != 1 || violations.GetViolations()[0].GetRuleId() != "string.min_len" {
+			t.Errorf("Unexpected violations: %v", violations)
+		}
+		if len(badRequest.GetFieldViolations()) != 1 {
+			t.Fatalf("Expected 1 field violation, got %v", badRequest)
+		}
+		fieldViolation := badRequest.GetFieldViolations()[0]
+		if fieldViolation.GetField() != "name" || fieldViolation.GetReason() != "STRING_MIN_LEN" || fieldViolation.GetDescription() == "" {
+			t.Errorf("Unexpected field violation: %v", fieldViolation)
+		}
+	})
+
+	t.Run("ValidationService request", func(t *testing.T) {
+		_, err := validationv1.NewValidationServiceClient(conn).ValidateProto(ctx, &validationv1.ValidateProtoRequest{})
+		violations, badRequest := validationDetails(t, err)
+
+		if len(violations.GetViolations()) != 2 || len(badRequest.GetFieldViolations()) != 2 {
+			t.Fatalf("Expected 2 violations, got %v and %v", violations, badRequest)
+		}
+		fields := map[string]string{}
+		for _, fieldViolation := range badRequest.GetFieldViolations() {
+			fields[fieldViolation.GetField()] = fieldViolation.GetReason()
+		}
+		if fields["schema_name"] != "REQUIRED" || fields["payload"] != "REQUIRED" {
+			t.Errorf("Unexpected field violations: %v", fields)
+		}
+	})
+}
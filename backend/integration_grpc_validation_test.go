@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "validation-service/backend/proto"
+	validationv1 "validation-service/backend/proto/validation/v1"
+)
+
+func TestGRPCValidationService(t *testing.T) {
+	client := validationv1.NewValidationServiceClient(startTestGRPCServer(t))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	t.Run("ValidateProto", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			req         *validationv1.ValidateProtoRequest
+			wantCode    codes.Code
+			wantSuccess bool
+			wantRuleIDs []string
+		}{
+			{
+				name:        "valid JSON payload",
+				req:         &validationv1.ValidateProtoRequest{SchemaName: "proto.HelloRequest", Payload: &validationv1.ValidateProtoRequest_PayloadJson{PayloadJson: `{"name": "John"}`}},
+				wantSuccess: true,
+			},
+			{
+				name:        "invalid JSON payload",
+				req:         &validationv1.ValidateProtoRequest{SchemaName: "proto.HelloRequest", Payload: &validationv1.ValidateProtoRequest_PayloadJson{PayloadJson: `{"name": "Jo"}`}},
+				wantRuleIDs: []string{"string.min_len"},
+			},
+			{
+				name:        "binary payload",
+				req:         &validationv1.ValidateProtoRequest{SchemaName: "proto.HelloRequest", Payload: &validationv1.ValidateProtoRequest_PayloadBinary{PayloadBinary: mustMarshal(t, &pb.HelloRequest{Name: "John"})}},
+				wantSuccess: true,
+			},
+			{
+				name:        "text payload",
+				req:         &validationv1.ValidateProtoRequest{SchemaName: "proto.ConditionalOrder", Payload: &validationv1.ValidateProtoRequest_PayloadText{PayloadText: `order_type: ORDER_TYPE_STANDARD`}},
+				wantSuccess: true,
+			},
+			{
+				name:     "unknown schema",
+				req:      &validationv1.ValidateProtoRequest{SchemaName: "proto.DoesNotExist", Payload: &validationv1.ValidateProtoRequest_PayloadJson{PayloadJson: `{}`}},
+				wantCode: codes.NotFound,
+			},
+			{
+				name:     "missing payload",
+				req:      &validationv1.ValidateProtoRequest{SchemaName: "proto.HelloRequest"},
+				wantCode: codes.InvalidArgument,
+			},
+			{
+				name:     "malformed JSON payload",
+				req:      &validationv1.ValidateProtoRequest{SchemaName: "proto.HelloRequest", Payload: &validationv1.ValidateProtoRequest_PayloadJson{PayloadJson: `{"name": `}},
+				wantCode: codes.InvalidArgument,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				resp, err := client.ValidateProto(ctx, tt.req)
+				if code := status.Code(err); code != tt.wantCode {
+					t.Fatalf("Expected code %s, got %s (%v)", tt.wantCode, code, err)
+				}
+				if err != nil {
+					return
+				}
+
+				if resp.GetSuccess() != tt.wantSuccess {
+					t.Errorf("Expected success=%v, got %v (errors: %v)", tt.wantSuccess, resp.GetSuccess(), resp.GetErrors())
+				}
+				if len(resp.GetErrors()) != len(tt.wantRuleIDs) {
+					t.Fatalf("Expected %d error(s), got %d: %v", len(tt.wantRuleIDs), len(resp.GetErrors()), resp.GetErrors())
+				}
+				for i, ruleID := range tt.wantRuleIDs {
+					if got := resp.GetErrors()[i]; got.GetRuleId() != ruleID || got.GetFriendly() == "" {
+						t.Errorf("Error %d: expected rule %s, got %v", i, ruleID, got)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("ValidationError value", func(t *testing.T) {
+		resp, err := client.ValidateProto(ctx, &validationv1.ValidateProtoRequest{
+			SchemaName: "proto.HelloRequest",
+			Payload:    &validationv1.ValidateProtoRequest_PayloadJson{PayloadJson: `{"name": "Jo"}`},
+		})
+		if err != nil {
+			t.Fatalf("ValidateProto failed: %v", err)
+		}
+		got := resp.GetErrors()[0]
+		if got.GetField() != "name" || got.GetPointer() != "/name" || got.GetValue().GetStringValue() != "Jo" {
+			t.Errorf("Unexpected structured error: %v", got)
+		}
+	})
+
+	t.Run("ValidateBatch", func(t *testing.T) {
+		resp, err := client.ValidateBatch(ctx, &validationv1.ValidateBatchRequest{
+			SchemaName: "proto.HelloRequest",
+			Items: []*validationv1.ValidateBatchItem{
+				{Payload: &validationv1.ValidateBatchItem_PayloadJson{PayloadJson: `{"name": "John"}`}},
+				{Payload: &validationv1.ValidateBatchItem_PayloadJson{PayloadJson: `{"name": "Jo"}`}},
+				{SchemaName: "proto.DeleteTask", Payload: &validationv1.ValidateBatchItem_PayloadText{PayloadText: `task_id: "task-1"`}},
+				{SchemaName: "proto.DoesNotExist", Payload: &validationv1.ValidateBatchItem_PayloadJson{PayloadJson: `{}`}},
+			},
+		})
+		if err != nil {
+			t.Fatalf("ValidateBatch failed: %v", err)
+		}
+
+		if resp.GetSuccess() || resp.GetTotal() != 4 || resp.GetValid() != 2 || resp.GetInvalid() != 2 {
+			t.Errorf("Unexpected summary: %v", resp)
+		}
+		wantSuccess := []bool{true, false, true, false}
+		for i, result := range resp.GetResults() {
+			if int(result.GetIndex()) != i || result.GetSuccess() != wantSuccess[i] {
+				t.Errorf("Result %d: unexpected %v", i, result)
+			}
+		}
+		if resp.GetResults()[3].GetError() == "" {
+			t.Errorf("Expected processing error for unknown schema")
+		}
+	})
+
+	t.Run("ValidateBatch rejects empty and oversized batches", func(t *testing.T) {
+		if _, err := client.ValidateBatch(ctx, &validationv1.ValidateBatchRequest{SchemaName: "proto.HelloRequest"}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for empty batch, got %v", err)
+		}
+
+		items := make([]*validationv1.ValidateBatchItem, 101)
+		for i := range items {
+			items[i] = &validationv1.ValidateBatchItem{Payload: &validationv1.ValidateBatchItem_PayloadJson{PayloadJson: `{"name": "John"}`}}
+		}
+		if _, err := client.ValidateBatch(ctx, &validationv1.ValidateBatchRequest{SchemaName: "proto.HelloRequest", Items: items}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for oversized batch, got %v", err)
+		}
+	})
+
+	t.Run("GetSchema", func(t *testing.T) {
+		resp, err := client.GetSchema(ctx, &validationv1.GetSchemaRequest{MessageName: "proto.HelloRequest"})
+		if err != nil {
+			t.Fatalf("GetSchema failed: %v", err)
+		}
+		var schema map[string]interface{}
+		if err := json.Unmarshal([]byte(resp.GetJsonSchema()), &schema); err != nil {
+			t.Fatalf("Expected JSON Schema document, got %q: %v", resp.GetJsonSchema(), err)
+		}
+
+		if _, err := client.GetSchema(ctx, &validationv1.GetSchemaRequest{MessageName: "proto.DoesNotExist"}); status.Code(err) != codes.NotFound {
+			t.Errorf("Expected NotFound for unknown message, got %v", err)
+		}
+	})
+
+	t.Run("ListMessages", func(t *testing.T) {
+		resp, err := client.ListMessages(ctx, &validationv1.ListMessagesRequest{})
+		if err != nil {
+			t.Fatalf("ListMessages failed: %v", err)
+		}
+
+		found := false
+		for _, message := range resp.GetMessages() {
+			if message.GetFullName() == "proto.Task" {
+				found = true
+			}
+			if message.GetFullName() == "validation.v1.ValidateProtoRequest" {
+				t.Errorf("Expected API messages to be excluded, got %s", message.GetFullName())
+			}
+		}
+		if !found {
+			t.Errorf("Expected proto.Task in %v", resp.GetMessages())
+		}
+	})
+}
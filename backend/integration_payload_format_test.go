@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "validation-service/backend/proto"
+)
+
+// postValidate posts a raw body to the validate-proto endpoint and decodes the response
+func postValidate(t *testing.T, url, contentType string, body []byte) (*validateProtoResponse, int) {
+	resp, err := http.Post(url, contentType, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result validateProtoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+// mustMarshal serializes a message to the protobuf wire format
+func mustMarshal(t *testing.T, msg proto.Message) []byte {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Failed to marshal message: %v", err)
+	}
+	return data
+}
+
+// mustMarshalJSON serializes a value to JSON
+func mustMarshalJSON(t *testing.T, v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to marshal JSON: %v", err)
+	}
+	return data
+}
+
+func TestPayloadFormatsValidationAPI(t *testing.T) {
+	baseURL := startTestServer(t)
+	validateURL := baseURL + "/api/v1/validate-proto"
+	rawURL := validateURL + "?schemaName=proto.HelloRequest"
+
+	tests := []struct {
+		name        string
+		url         string
+		contentType string
+		body        []byte
+		wantStatus  int
+		wantSuccess bool
+		wantErrors  int
+	}{
+		{
+			name:        "binary body valid",
+			url:         rawURL,
+			contentType: "application/x-protobuf",
+			body:        mustMarshal(t, &pb.HelloRequest{Name: "John"}),
+			wantStatus:  http.StatusOK,
+			wantSuccess: true,
+		},
+		{
+			name:        "binary body invalid",
+			url:         rawURL,
+			contentType: "application/x-protobuf",
+			body:        mustMarshal(t, &pb.HelloRequest{Name: "Jo"}),
+			wantStatus:  http.StatusOK,
+			wantErrors:  1,
+		},
+		{
+			name:        "empty binary body is the default message",
+			url:         rawURL,
+			contentType: "application/x-protobuf",
+			body:        []byte{},
+			wantStatus:  http.StatusOK,
+			wantErrors:  1,
+		},
+		{
+			name:        "malformed binary body",
+			url:         rawURL,
+			contentType: "application/x-protobuf",
+			body:        []byte{0x0a, 0x05, 'J'},
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "binary body without schemaName",
+			url:         validateURL,
+			contentType: "application/x-protobuf",
+			body:        mustMarshal(t, &pb.HelloRequest{Name: "John"}),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "text format body",
+			url:         rawURL,
+			contentType: "text/x-protobuf",
+			body:        []byte(`name: "Jo"`),
+			wantStatus:  http.StatusOK,
+			wantErrors:  1,
+		},
+		{
+			name:        "base64 binary in JSON envelope",
+			url:         validateURL,
+			contentType: "application/json",
+			body: mustMarshalJSON(t, map[string]interface{}{
+				"schemaName":    "proto.HelloRequest",
+				"payloadBinary": mustMarshal(t, &pb.HelloRequest{Name: "John"}),
+			}),
+			wantStatus:  http.StatusOK,
+			wantSuccess: true,
+		},
+		{
+			name:        "text format in JSON envelope",
+			url:         validateURL,
+			contentType: "application/json",
+			body: mustMarshalJSON(t, map[string]interface{}{
+				"schemaName":  "proto.Task",
+				"payloadText": `name: "Task" timestamp { seconds: 1705314600 } status: TASK_STATUS_OPEN`,
+			}),
+			wantStatus:  http.StatusOK,
+			wantSuccess: true,
+		},
+		{
+			name:        "multiple payloads in JSON envelope",
+			url:         validateURL,
+			contentType: "application/json",
+			body: mustMarshalJSON(t, map[string]interface{}{
+				"schemaName":  "proto.HelloRequest",
+				"payload":     map[string]interface{}{"name": "John"},
+				"payloadText": `name: "John"`,
+			}),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, statusCode := postValidate(t, tt.url, tt.contentType, tt.body)
+			if statusCode != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, statusCode)
+			}
+			if result == nil {
+				return
+			}
+
+			if result.Success != tt.wantSuccess {
+				t.Errorf("Expected success=%v, got success=%v. Errors: %v", tt.wantSuccess, result.Success, result.Errors)
+			}
+			if len(result.Errors) != tt.wantErrors {
+				t.Errorf("Expected %d validation errors, got %d. Errors: %v", tt.wantErrors, len(result.Errors), result.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"validation-service/backend/handler"
+	"validation-service/backend/logger"
+	validationv1 "validation-service/backend/proto/validation/v1"
+	"validation-service/backend/service"
+
+	"buf.build/go/protovalidate"
+)
+
+// resolutionResponse is the part of a validation response describing the definitions used
+type resolutionResponse struct {
+	Success        bool   `json:"success"`
+	ResolvedCommit string `json:"resolvedCommit"`
+	Source         string `json:"source"`
+	Digest         string `json:"digest"`
+}
+
+func TestValidationResolutionAPI(t *testing.T) {
+	baseURL := startTestServer(t)
+
+	post := func(t *testing.T, commit string) (*http.Response, []byte) {
+		body, _ := json.Marshal(map[string]interface{}{
+			"schemaName": "proto.SimpleUser",
+			"payload":    map[string]interface{}{"email": "user@example.com", "name": "Jane Doe", "age": 30},
+			"commit":     commit,
+		})
+		resp, err := http.Post(baseURL+"/api/v1/validate-proto", "application/json", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(resp.Body)
+		return resp, buf.Bytes()
+	}
+
+	t.Run("ReportsLocalResolution", func(t *testing.T) {
+		resp, body := post(t, "")
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", resp.StatusCode, body)
+		}
+		var result resolutionResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if result.Source != "local" || result.ResolvedCommit != "local" || !strings.HasPrefix(result.Digest, "sha256:") {
+			t.Errorf("Unexpected resolution: %+v", result)
+		}
+	})
+
+	t.Run("SpecificCommitFailsInLocalOnly", func(t *testing.T) {
+		resp, body := post(t, "0123456789abcdef0123456789abcdef")
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("Expected 400, got %d: %s", resp.StatusCode, body)
+		}
+		if !strings.Contains(string(body), "is not available") {
+			t.Errorf("Expected explicit commit error, got %s", body)
+		}
+	})
+}
+
+func TestGRPCResolution(t *testing.T) {
+	conn := startTestGRPCServer(t)
+	client := validationv1.NewValidationServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.ValidateProto(ctx, &validationv1.ValidateProtoRequest{
+		SchemaName: "proto.SimpleUser",
+		Payload:    &validationv1.ValidateProtoRequest_PayloadJson{PayloadJson: `{"email": "user@example.com", "name": "Jane Doe", "age": 30}`},
+	})
+	if err != nil {
+		t.Fatalf("ValidateProto failed: %v", err)
+	}
+	if resp.GetResolution().GetSource() != "local" || !strings.HasPrefix(resp.GetResolution().GetDigest(), "sha256:") {
+		t.Errorf("Unexpected resolution: %v", resp.GetResolution())
+	}
+
+	_, err = client.ValidateProto(ctx, &validationv1.ValidateProtoRequest{
+		SchemaName: "proto.SimpleUser",
+		Payload:    &validationv1.ValidateProtoRequest_PayloadJson{PayloadJson: `{}`},
+		Commit:     "v1.2.0",
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for a specific commit, got %v", err)
+	}
+
+	schema, err := client.GetSchema(ctx, &validationv1.GetSchemaRequest{MessageName: "proto.SimpleUser"})
+	if err != nil {
+		t.Fatalf("GetSchema failed: %v", err)
+	}
+	if schema.GetResolution().GetSource() != "local" || !strings.HasPrefix(schema.GetResolution().GetDigest(), "sha256:") {
+		t.Errorf("Unexpected schema resolution: %v", schema.GetResolution())
+	}
+
+	// Local schemas are the named version "local", any other commit fails explicitly
+	if _, err := client.GetSchema(ctx, &validationv1.GetSchemaRequest{MessageName: "proto.SimpleUser", Commit: "local"}); err != nil {
+		t.Errorf("GetSchema at local failed: %v", err)
+	}
+	_, err = client.GetSchema(ctx, &validationv1.GetSchemaRequest{MessageName: "proto.SimpleUser", Commit: "0123456789abcdef0123456789abcdef"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for a pinned schema commit, got %v", err)
+	}
+}
+
+// failingSource is a descriptor and schema source whose upstream is unreachable
+type failingSource struct{}
+
+func (failingSource) Name() string { return "bsr" }
+
+func (failingSource) FindMessage(schemaName, version string) (*service.ResolvedMessage, error) {
+	return nil, errors.New("HTTP request failed: connection refused")
+}
+
+func (failingSource) GetSchema(messageName, version string, variant service.SchemaVariant) (*service.ResolvedSchema, error) {
+	return nil, errors.New("HTTP request failed: connection refused")
+}
+
+func TestSourceErrorStatusesMatchAcrossAPIs(t *testing.T) {
+	logger.Init()
+	validator, err := protovalidate.New()
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	local := service.NewRegistryDescriptorSource(nil)
+	localSchemas := service.NewLocalSchemaSource(filepath.Join("gen", "jsonschema"))
+	validationHandler := handler.NewValidationHandler(service.NewValidationService(validator, failingSource{}, false), 4, 100, 1<<20)
+	schemaHandler := handler.NewSchemaHandler(service.NewSchemaService(failingSource{}))
+	localValidationHandler := handler.NewValidationHandler(service.NewValidationService(validator, local, false), 4, 100, 1<<20)
+	localSchemaHandler := handler.NewSchemaHandler(service.NewSchemaService(localSchemas))
+
+	validate := func(h *handler.ValidationHandler, schemaName string) int {
+		body := strings.NewReader(`{"schemaName": "` + schemaName + `", "payload": {}}`)
+		rec := httptest.NewRecorder()
+		h.ValidateProto(rec, httptest.NewRequest(http.MethodPost, "/api/v1/validate-proto", body))
+		return rec.Code
+	}
+	getSchema := func(h *handler.SchemaHandler, messageName string) int {
+		rec := httptest.NewRecorder()
+		h.GetSchema(rec, httptest.NewRequest(http.MethodGet, "/api/v1/schema/"+messageName, nil))
+		return rec.Code
+	}
+
+	if got := validate(validationHandler, "proto.SimpleUser"); got != http.StatusBadGateway {
+		t.Errorf("Expected 502 from validate-proto when the source fails, got %d", got)
+	}
+	if got := getSchema(schemaHandler, "proto.SimpleUser"); got != http.StatusBadGateway {
+		t.Errorf("Expected 502 from schema when the source fails, got %d", got)
+	}
+	if got := validate(localValidationHandler, "proto.DoesNotExist"); got != http.StatusBadRequest {
+		t.Errorf("Expected 400 from validate-proto for an unknown schema, got %d", got)
+	}
+	if got := getSchema(localSchemaHandler, "proto.DoesNotExist"); got != http.StatusNotFound {
+		t.Errorf("Expected 404 from schema for an unknown schema, got %d", got)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// streamRecord represents a single NDJSON record from the stream validation API
+type streamRecord struct {
+	Line    int    `json:"line"`
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+	Errors  []struct {
+		Friendly  string `json:"friendly"`
+		Technical string `json:"technical"`
+	} `json:"errors"`
+	Summary bool `json:"summary"`
+	Lines   int  `json:"lines"`
+	Total   int  `json:"total"`
+	Valid   int  `json:"valid"`
+	Invalid int  `json:"invalid"`
+}
+
+// streamURL builds the stream validation URL for a schema
+func streamURL(baseURL, schemaName string) string {
+	return fmt.Sprintf("%s/api/v1/validate-proto/stream?schemaName=%s", baseURL, url.QueryEscape(schemaName))
+}
+
+func TestStreamValidationAPI(t *testing.T) {
+	baseURL := startTestServer(t)
+
+	body := strings.Join([]string{
+		`{"name": "John"}`,
+		`{"name": "Jo"}`,
+		``,
+		`{not json}`,
+		`{"name": "Jonathan"}`,
+	}, "\n")
+
+	resp, err := http.Post(streamURL(baseURL, "proto.HelloRequest"), "application/x-ndjson", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Expected Content-Type application/x-ndjson, got %s", ct)
+	}
+
+	var records []streamRecord
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var record streamRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Failed to decode record %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+
+	if len(records) != 5 {
+		t.Fatalf("Expected 4 line records and 1 summary, got %d records", len(records))
+	}
+
+	wantLines := []int{1, 2, 4, 5}
+	wantSuccess := []bool{true, false, false, true}
+	for i, record := range records[:4] {
+		if record.Line != wantLines[i] || record.Success != wantSuccess[i] {
+			t.Errorf("Record %d: expected line=%d success=%v, got line=%d success=%v", i, wantLines[i], wantSuccess[i], record.Line, record.Success)
+		}
+	}
+	if len(records[1].Errors) != 1 {
+		t.Errorf("Expected 1 validation error on line 2, got %d", len(records[1].Errors))
+	}
+	if records[2].Error == "" {
+		t.Errorf("Expected processing error on line 4")
+	}
+
+	summary := records[4]
+	if !summary.Summary || summary.Lines != 5 || summary.Total != 4 || summary.Valid != 2 || summary.Invalid != 2 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+}
+
+func TestStreamValidationAPIStreamsIncrementally(t *testing.T) {
+	baseURL := startTestServer(t)
+
+	bodyReader, bodyWriter := io.Pipe()
+	defer bodyWriter.Close()
+
+	req, err := http.NewRequest(http.MethodPost, streamURL(baseURL, "proto.HelloRequest"), bodyReader)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	// Send the first line before the response is requested so the server can start streaming
+	go fmt.Fprintln(bodyWriter, `{"name": "John"}`)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	readRecord := func() streamRecord {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			t.Fatalf("Failed to read record: %v", err)
+		}
+		var record streamRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Fatalf("Failed to decode record %q: %v", line, err)
+		}
+		return record
+	}
+
+	// The first result must arrive while the request body is still open
+	if record := readRecord(); record.Line != 1 || !record.Success {
+		t.Fatalf("Unexpected first record: %+v", record)
+	}
+
+	fmt.Fprintln(bodyWriter, `{"name": "Jo"}`)
+	if record := readRecord(); record.Line != 2 || record.Success {
+		t.Fatalf("Unexpected second record: %+v", record)
+	}
+
+	bodyWriter.Close()
+	if summary := readRecord(); !summary.Summary || summary.Total != 2 {
+		t.Fatalf("Unexpected summary: %+v", summary)
+	}
+}
+
+func TestStreamValidationAPIUnknownSchema(t *testing.T) {
+	baseURL := startTestServer(t)
+
+	resp, err := http.Post(streamURL(baseURL, "proto.DoesNotExist"), "application/x-ndjson", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", resp.StatusCode)
+	}
+}
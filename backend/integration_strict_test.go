@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	pb "validation-service/backend/proto"
+)
+
+// callValidateAPIStrict posts a JSON envelope with an explicit strict flag and decodes the structured errors
+func callValidateAPIStrict(t *testing.T, baseURL, schemaName string, payload interface{}, strict bool) (bool, []structuredError) {
+	body := mustMarshalJSON(t, map[string]interface{}{
+		"schemaName": schemaName,
+		"payload":    payload,
+		"strict":     strict,
+	})
+
+	resp, err := http.Post(baseURL+"/api/v1/validate-proto", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Success bool              `json:"success"`
+		Errors  []structuredError `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return result.Success, result.Errors
+}
+
+func TestStrictModeValidationAPI(t *testing.T) {
+	baseURL := startTestServer(t)
+
+	typoOrder := map[string]interface{}{"orderType": "ORDER_TYPE_EXPRESS", "expresFee": 5}
+
+	t.Run("lenient mode drops unknown fields", func(t *testing.T) {
+		success, errors := callValidateAPIStrict(t, baseURL, "proto.ConditionalOrder", typoOrder, false)
+		if success || len(errors) != 1 {
+			t.Fatalf("Expected only the CEL violation, got success=%v, errors=%+v", success, errors)
+		}
+		if errors[0].ConstraintID != "express_fee_required" {
+			t.Errorf("Expected constraintId express_fee_required, got %q", errors[0].ConstraintID)
+		}
+	})
+
+	t.Run("strict mode reports unknown fields", func(t *testing.T) {
+		success, errors := callValidateAPIStrict(t, baseURL, "proto.ConditionalOrder", typoOrder, true)
+		if success || len(errors) != 2 {
+			t.Fatalf("Expected unknown field and CEL violation, got success=%v, errors=%+v", success, errors)
+		}
+
+		got := errors[0]
+		if got.RuleID != "unknown_field" || got.Field != "expresFee" || got.JSONField != "expresFee" || got.Pointer != "/expresFee" {
+			t.Errorf("Unexpected unknown field error: %+v", got)
+		}
+		if !strings.Contains(got.Friendly, "expresFee") {
+			t.Errorf("Expected friendly message to name the field, got %q", got.Friendly)
+		}
+		if errors[1].ConstraintID != "express_fee_required" {
+			t.Errorf("Expected constraintId express_fee_required, got %q", errors[1].ConstraintID)
+		}
+	})
+
+	t.Run("strict mode reports nested unknown fields", func(t *testing.T) {
+		order := map[string]interface{}{
+			"customer": map[string]interface{}{"email": "jane@example.com", "phone": "+1234567890", "name": "Jane", "address": "1 Market Street"},
+			"items": []interface{}{
+				map[string]interface{}{"productId": "sku-1", "quantity": 1, "price": 9.99, "colour": "red"},
+			},
+			"shipping": map[string]interface{}{"type": "SHIPPING_TYPE_DIGITAL"},
+			"total":    9.99,
+		}
+
+		success, errors := callValidateAPIStrict(t, baseURL, "proto.ComplexOrder", order, true)
+		if success || len(errors) != 1 {
+			t.Fatalf("Expected 1 unknown field error, got success=%v, errors=%+v", success, errors)
+		}
+		if got := errors[0]; got.Field != "items[0].colour" || got.JSONField != "items[0].colour" || got.Pointer != "/items/0/colour" || got.Value != "red" {
+			t.Errorf("Unexpected unknown field error: %+v", got)
+		}
+	})
+
+	t.Run("proto field names are not unknown", func(t *testing.T) {
+		success, errors := callValidateAPIStrict(t, baseURL, "proto.ConditionalOrder", map[string]interface{}{"order_type": "ORDER_TYPE_EXPRESS", "express_fee": 5}, true)
+		if !success {
+			t.Errorf("Expected success, got errors: %+v", errors)
+		}
+	})
+
+	t.Run("strict mode reports unknown binary fields", func(t *testing.T) {
+		body := mustMarshal(t, &pb.HelloRequest{Name: "John"})
+		body = protowire.AppendTag(body, 99, protowire.VarintType)
+		body = protowire.AppendVarint(body, 1)
+
+		rawURL := baseURL + "/api/v1/validate-proto?schemaName=proto.HelloRequest"
+		result, statusCode := postValidate(t, rawURL, "application/x-protobuf", body)
+		if statusCode != http.StatusOK || !result.Success {
+			t.Fatalf("Expected lenient success, got status=%d, result=%+v", statusCode, result)
+		}
+
+		result, statusCode = postValidate(t, rawURL+"&strict=true", "application/x-protobuf", body)
+		if statusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", statusCode)
+		}
+		if result.Success || len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Friendly, "field number 99") {
+			t.Errorf("Expected unknown field number 99, got %+v", result)
+		}
+	})
+
+	t.Run("strict mode reports nested unknown binary fields with both paths", func(t *testing.T) {
+		personalInfo := protowire.AppendTag(nil, 99, protowire.VarintType)
+		personalInfo = protowire.AppendVarint(personalInfo, 1)
+		body := protowire.AppendTag(nil, 1, protowire.BytesType)
+		body = protowire.AppendBytes(body, personalInfo)
+
+		result, statusCode := postValidate(t, baseURL+"/api/v1/validate-proto?schemaName=proto.EmployeeProfile&strict=true", "application/x-protobuf", body)
+		if statusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", statusCode)
+		}
+		for _, got := range result.Errors {
+			if got.RuleID != "unknown_field" {
+				continue
+			}
+			if got.Field != "personal_info" || got.JSONField != "personalInfo" || got.Pointer != "/personalInfo" {
+				t.Errorf("Unexpected unknown field error: %+v", got)
+			}
+			return
+		}
+		t.Errorf("Expected an unknown field error, got %+v", result.Errors)
+	})
+
+	t.Run("invalid strict parameter", func(t *testing.T) {
+		_, statusCode := postValidate(t, baseURL+"/api/v1/validate-proto?schemaName=proto.HelloRequest&strict=maybe", "application/x-protobuf", nil)
+		if statusCode != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", statusCode)
+		}
+	})
+
+	t.Run("batch applies top-level strict", func(t *testing.T) {
+		result, statusCode := callBatchValidateAPI(t, baseURL, map[string]interface{}{
+			"schemaName": "proto.HelloRequest",
+			"strict":     true,
+			"items": []map[string]interface{}{
+				{"payload": map[string]interface{}{"name": "John", "nmae": "x"}},
+				{"payload": map[string]interface{}{"name": "John", "nmae": "x"}, "strict": false},
+			},
+		})
+		if statusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", statusCode)
+		}
+		if result.Results[0].Success || !result.Results[1].Success {
+			t.Errorf("Expected only the first item to fail, got %+v", result.Results)
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "validation-service/backend/proto"
+	taskv1 "validation-service/backend/proto/task/v1"
+)
+
+// doTaskRequest sends a task API request and decodes the JSON response into out, if set
+func doTaskRequest(t *testing.T, method, url string, body string, out interface{}) int {
+	req, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil && resp.Header.Get("Content-Type") == "application/json" {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// taskViolationResponse is the body of a task API request rejected by validation
+type taskViolationResponse struct {
+	Success bool              `json:"success"`
+	Errors  []structuredError `json:"errors"`
+}
+
+func TestTaskServiceHTTPAPI(t *testing.T) {
+	baseURL := startTestServer(t) + "/api/v1/tasks"
+
+	var created struct {
+		ID   string                 `json:"id"`
+		Task map[string]interface{} `json:"task"`
+	}
+	statusCode := doTaskRequest(t, http.MethodPost, baseURL, `{"task": {"name": "Ship it", "timestamp": "2024-01-15T10:30:00Z", "status": "TASK_STATUS_OPEN"}}`, &created)
+	if statusCode != http.StatusCreated || created.ID == "" {
+		t.Fatalf("Expected 201 with task ID, got %d (%+v)", statusCode, created)
+	}
+
+	t.Run("invalid create reports violations", func(t *testing.T) {
+		var result taskViolationResponse
+		statusCode := doTaskRequest(t, http.MethodPost, baseURL, `{"task": {"name": "", "timestamp": "2024-01-15T10:30:00Z", "status": "TASK_STATUS_OPEN"}}`, &result)
+		if statusCode != http.StatusBadRequest || result.Success || len(result.Errors) != 1 || result.Errors[0].Pointer != "/task/name" {
+			t.Fatalf("Expected 400 with 1 violation at /task/name, got %d (%+v)", statusCode, result)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		if statusCode := doTaskRequest(t, http.MethodGet, baseURL+"/"+created.ID, "", nil); statusCode != http.StatusOK {
+			t.Errorf("Expected 200, got %d", statusCode)
+		}
+		if statusCode := doTaskRequest(t, http.MethodGet, baseURL+"/does-not-exist", "", nil); statusCode != http.StatusNotFound {
+			t.Errorf("Expected 404 for unknown task, got %d", statusCode)
+		}
+	})
+
+	t.Run("blocked update requires comment", func(t *testing.T) {
+		var result taskViolationResponse
+		statusCode := doTaskRequest(t, http.MethodPatch, baseURL+"/"+created.ID, `{"update": {"status": "TASK_STATUS_BLOCKED"}}`, &result)
+		if statusCode != http.StatusBadRequest || len(result.Errors) != 1 || result.Errors[0].ConstraintID != "comment_required_if_blocked" {
+			t.Fatalf("Expected comment_required_if_blocked violation, got %d (%+v)", statusCode, result)
+		}
+
+		var updated struct {
+			Comment string `json:"comment"`
+		}
+		statusCode = doTaskRequest(t, http.MethodPatch, baseURL+"/"+created.ID, `{"update": {"status": "TASK_STATUS_BLOCKED", "comment": "waiting"}}`, &updated)
+		if statusCode != http.StatusOK || updated.Comment != "waiting" {
+			t.Errorf("Expected 200 with comment, got %d (%+v)", statusCode, updated)
+		}
+	})
+
+	t.Run("list by status", func(t *testing.T) {
+		var list struct {
+			Tasks []json.RawMessage `json:"tasks"`
+		}
+		if statusCode := doTaskRequest(t, http.MethodGet, baseURL+"?status=TASK_STATUS_BLOCKED", "", &list); statusCode != http.StatusOK || len(list.Tasks) != 1 {
+			t.Errorf("Expected 1 blocked task, got %d (%d task(s))", statusCode, len(list.Tasks))
+		}
+		if statusCode := doTaskRequest(t, http.MethodGet, baseURL+"?pageSize=1000", "", nil); statusCode != http.StatusBadRequest {
+			t.Errorf("Expected 400 for oversized page, got %d", statusCode)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if statusCode := doTaskRequest(t, http.MethodDelete, baseURL+"/"+created.ID+"?comment=done", "", nil); statusCode != http.StatusOK {
+			t.Errorf("Expected 200, got %d", statusCode)
+		}
+		if statusCode := doTaskRequest(t, http.MethodDelete, baseURL+"/"+created.ID, "", nil); statusCode != http.StatusNotFound {
+			t.Errorf("Expected 404 for deleted task, got %d", statusCode)
+		}
+	})
+}
+
+func TestTaskServiceGRPC(t *testing.T) {
+	client := taskv1.NewTaskServiceClient(startTestGRPCServer(t))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	created, err := client.CreateTask(ctx, &taskv1.CreateTaskRequest{
+		Task: &pb.Task{Name: "Ship it", Timestamp: timestamppb.Now(), Status: pb.TaskStatus_TASK_STATUS_OPEN},
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantCode codes.Code
+	}{
+		{
+			name: "get",
+			call: func() error {
+				_, err := client.GetTask(ctx, &taskv1.GetTaskRequest{Id: created.GetId()})
+				return err
+			},
+		},
+		{
+			name: "create without task",
+			call: func() error {
+				_, err := client.CreateTask(ctx, &taskv1.CreateTaskRequest{})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "update to unspecified status",
+			call: func() error {
+				_, err := client.UpdateTaskStatus(ctx, &taskv1.UpdateTaskStatusRequest{Id: created.GetId(), Update: &pb.UpdateTask{}})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "update unknown task",
+			call: func() error {
+				_, err := client.UpdateTaskStatus(ctx, &taskv1.UpdateTaskStatusRequest{Id: "does-not-exist", Update: &pb.UpdateTask{Status: pb.TaskStatus_TASK_STATUS_COMPLETED}})
+				return err
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "list with invalid page token",
+			call: func() error {
+				_, err := client.ListTasks(ctx, &taskv1.ListTasksRequest{PageToken: "bogus"})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "delete without ID",
+			call: func() error {
+				_, err := client.DeleteTask(ctx, &pb.DeleteTask{})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "delete",
+			call: func() error {
+				_, err := client.DeleteTask(ctx, &pb.DeleteTask{TaskId: created.GetId(), Comment: "done"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(tt.call()); code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, code)
+			}
+		})
+	}
+}
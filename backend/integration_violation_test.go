@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// structuredError represents a validation error including its structured violation details
+type structuredError struct {
+	Friendly     string      `json:"friendly"`
+	Technical    string      `json:"technical"`
+	Field        string      `json:"field"`
+	JSONField    string      `json:"jsonField"`
+	Pointer      string      `json:"pointer"`
+	RuleID       string      `json:"ruleId"`
+	ConstraintID string      `json:"constraintId"`
+	Value        interface{} `json:"value"`
+	ForKey       bool        `json:"forKey"`
+}
+
+// callValidateAPIStructured makes a POST request to the validate-proto endpoint and
+// decodes the structured violation details of every error
+func callValidateAPIStructured(t *testing.T, baseURL string, schemaName string, payload interface{}) []structuredError {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Failed to marshal payload: %v", err)
+	}
+	reqBytes, err := json.Marshal(validateProtoRequest{SchemaName: schemaName, Payload: payloadBytes})
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	resp, err := http.Post(baseURL+"/api/v1/validate-proto", "application/json", bytes.NewBuffer(reqBytes))
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Success bool              `json:"success"`
+		Errors  []structuredError `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return result.Errors
+}
+
+func TestStructuredViolationsAPI(t *testing.T) {
+	baseURL := startTestServer(t)
+
+	validOrderItem := map[string]interface{}{"productId": "sku-1", "quantity": 1, "price": 9.99}
+	validOrder := func(items ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"customer": map[string]interface{}{"email": "jane@example.com", "phone": "+1234567890", "name": "Jane", "address": "1 Market Street"},
+			"items":    items,
+			"shipping": map[string]interface{}{"type": "SHIPPING_TYPE_DIGITAL"},
+			"total":    9.99,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		schemaName string
+		payload    interface{}
+		want       structuredError
+	}{
+		{
+			name:       "standard string rule",
+			schemaName: "proto.HelloRequest",
+			payload:    map[string]interface{}{"name": "Jo"},
+			want:       structuredError{Field: "name", JSONField: "name", Pointer: "/name", RuleID: "string.min_len", Value: "Jo"},
+		},
+		{
+			name:       "message level CEL constraint",
+			schemaName: "proto.UpdateTask",
+			payload:    map[string]interface{}{"status": "TASK_STATUS_BLOCKED"},
+			want:       structuredError{RuleID: "comment_required_if_blocked", ConstraintID: "comment_required_if_blocked"},
+		},
+		{
+			name:       "enum rule reports enum name",
+			schemaName: "proto.Task",
+			payload:    map[string]interface{}{"name": "Task", "timestamp": "2024-01-15T10:30:00Z", "status": 99},
+			want:       structuredError{Field: "status", JSONField: "status", Pointer: "/status", RuleID: "enum.defined_only", Value: float64(99)},
+		},
+		{
+			name:       "repeated nested field uses JSON names",
+			schemaName: "proto.ComplexOrder",
+			payload:    validOrder(validOrderItem, map[string]interface{}{"productId": "sku-2", "quantity": 2000, "price": 1, "discount": 5}),
+			want:       structuredError{Field: "items[1].quantity", JSONField: "items[1].quantity", Pointer: "/items/1/quantity", RuleID: "int32.gte_lte", Value: float64(2000)},
+		},
+		{
+			name:       "snake case field path",
+			schemaName: "proto.ComplexOrder",
+			payload:    validOrder(map[string]interface{}{"productId": "", "quantity": 1, "price": 1}),
+			want:       structuredError{Field: "items[0].product_id", JSONField: "items[0].productId", Pointer: "/items/0/productId", RuleID: "required"},
+		},
+		{
+			name:       "nested CEL constraint",
+			schemaName: "proto.ComplexOrder",
+			payload:    validOrder(map[string]interface{}{"productId": "sku-3", "quantity": 20, "price": 1}),
+			want:       structuredError{Field: "items[0]", JSONField: "items[0]", Pointer: "/items/0", RuleID: "discount_required_for_bulk", ConstraintID: "discount_required_for_bulk"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := callValidateAPIStructured(t, baseURL, tt.schemaName, tt.payload)
+			if len(errors) != 1 {
+				t.Fatalf("Expected 1 validation error, got %d: %+v", len(errors), errors)
+			}
+
+			got := errors[0]
+			if got.Friendly == "" || got.Technical == "" {
+				t.Errorf("Expected friendly and technical messages to be preserved, got %+v", got)
+			}
+			if got.Field != tt.want.Field || got.JSONField != tt.want.JSONField {
+				t.Errorf("Expected field=%q jsonField=%q, got field=%q jsonField=%q", tt.want.Field, tt.want.JSONField, got.Field, got.JSONField)
+			}
+			if got.Pointer != tt.want.Pointer {
+				t.Errorf("Expected pointer=%q, got %q", tt.want.Pointer, got.Pointer)
+			}
+			if got.RuleID != tt.want.RuleID || got.ConstraintID != tt.want.ConstraintID {
+				t.Errorf("Expected ruleId=%q constraintId=%q, got ruleId=%q constraintId=%q", tt.want.RuleID, tt.want.ConstraintID, got.RuleID, got.ConstraintID)
+			}
+			if tt.want.Value != nil && got.Value != tt.want.Value {
+				t.Errorf("Expected value=%v, got %v", tt.want.Value, got.Value)
+			}
+			if got.ForKey {
+				t.Errorf("Expected forKey=false, got true")
+			}
+		})
+	}
+}
@@ -3,18 +3,28 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
+	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
 	"validation-service/backend/config"
+	"validation-service/backend/connectserver"
+	"validation-service/backend/grpcserver"
 	"validation-service/backend/handler"
 	"validation-service/backend/logger"
 	"validation-service/backend/proto"
+	"validation-service/backend/proto/protoconnect"
+	taskv1 "validation-service/backend/proto/task/v1"
+	"validation-service/backend/proto/task/v1/taskv1connect"
+	validationv1 "validation-service/backend/proto/validation/v1"
+	"validation-service/backend/proto/validation/v1/validationv1connect"
 	"validation-service/backend/service"
 
 	"buf.build/go/protovalidate"
@@ -23,16 +33,10 @@ import (
 // greetingServer implements the GreetingServiceServer interface
 type greetingServer struct {
 	proto.UnimplementedGreetingServiceServer
-	validator protovalidate.Validator
 }
 
 // SayHello implements the SayHello RPC method
 func (s *greetingServer) SayHello(ctx context.Context, req *proto.HelloRequest) (*proto.HelloResponse, error) {
-	// Validate the request
-	if err := s.validator.Validate(req); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
-	}
-
 	return &proto.HelloResponse{
 		Message: "hello " + req.GetName(),
 	}, nil
@@ -44,7 +48,9 @@ func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 		// Set CORS headers to allow all origins
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, Connect-Protocol-Version, Connect-Timeout-Ms, Grpc-Timeout, X-Grpc-Web, X-User-Agent")
+		// Let browsers read the gRPC-Web status trailers of Connect handler responses
+		w.Header().Set("Access-Control-Expose-Headers", "Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin, X-Schema-Commit, X-Schema-Source, X-Schema-Digest, X-Schema-Module-Digest, X-Schema-Stale")
 		w.Header().Set("Access-Control-Max-Age", "3600")
 
 		// Handle preflight OPTIONS requests
@@ -74,6 +80,42 @@ func helloHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// runSnapshotCommand implements "snapshot": it downloads the descriptors and schema
+// artifacts of a commit or label of the BSR module into a bundle the server serves in the
+// snapshot source mode without network access
+// Returns the exit code
+func runSnapshotCommand(basePath string, args []string) int {
+	flags := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: validation-service snapshot [-module buf.build/{org}/{module}] [-version main] [-out dir]")
+		flags.PrintDefaults()
+	}
+	bsrOrg, bsrModule := service.GetBSRConfig(basePath)
+	module := flags.String("module", fmt.Sprintf("buf.build/%s/%s", bsrOrg, bsrModule), "BSR module to snapshot")
+	version := flags.String("version", "main", "Commit ID or label to snapshot")
+	out := flags.String("out", config.GetEnv("SNAPSHOT_PATH", filepath.Join(basePath, "snapshots")), "Directory to write the bundle to")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	parts := strings.Split(strings.TrimPrefix(*module, "buf.build/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		fmt.Fprintf(os.Stderr, "invalid module %q, expected buf.build/{org}/{module}\n", *module)
+		return 2
+	}
+	bsrToken := config.GetEnv("BUF_TOKEN", "")
+	manifest, bundle, err := service.CreateSnapshot(
+		service.NewBSRDescriptorSource(parts[0], parts[1], bsrToken),
+		service.NewBSRSchemaSource(parts[0], parts[1], bsrToken, 0),
+		*version, *out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Snapshot of %s at %s (commit %s, %d schemas) written to %s\n", manifest.Module, manifest.Version, manifest.Commit, manifest.Schemas, bundle)
+	return 0
+}
+
 func main() {
 	// Load environment variables from .env file
 	// This must be called before any code that reads environment variables
@@ -84,6 +126,18 @@ func main() {
 	// Initialize logger with level from environment variable
 	logger.Init()
 
+	// Get base path (directory where main.go is located)
+	logger.Debug("Resolving base path...")
+	basePath, err := filepath.Abs(".")
+	if err != nil {
+		logger.Fatal("Failed to get base path: %v", err)
+	}
+	logger.Debug("Base path resolved to: %s", basePath)
+
+	if len(os.Args) > 1 && os.Args[1] == "snapshot" {
+		os.Exit(runSnapshotCommand(basePath, os.Args[2:]))
+	}
+
 	logger.Info("Starting validation service...")
 
 	// Create validator instance
@@ -94,54 +148,149 @@ func main() {
 	}
 	logger.Info("Protovalidate validator initialized successfully")
 
-	// Get base path (directory where main.go is located)
-	logger.Debug("Resolving base path...")
-	basePath, err := filepath.Abs(".")
-	if err != nil {
-		logger.Fatal("Failed to get base path: %v", err)
-	}
-	logger.Debug("Base path resolved to: %s", basePath)
-
 	// Parse BSR configuration from buf.yaml
 	logger.Debug("Parsing BSR configuration from buf.yaml...")
 	bsrOrg, bsrModule := service.GetBSRConfig(basePath)
 	logger.Info("BSR configuration: org=%s, module=%s", bsrOrg, bsrModule)
 
+	// Get BSR token for BSR sources and the commits service
+	bsrToken := config.GetEnv("BUF_TOKEN", "")
+	if bsrToken == "" {
+		logger.Warn("BUF_TOKEN is not set. BSR requests may fail for private repositories.")
+	} else {
+		logger.Debug("BUF_TOKEN is set (length: %d)", len(bsrToken))
+	}
+
+	// Get validation source mode from environment variable for validation service
+	validationSourceMode := config.GetSchemaSourceMode("validation")
+	logger.Info("Validation source mode: %d", validationSourceMode)
+
+	// Initialize descriptor cache for BSR lookups
+	descriptorCacheTTL := config.GetEnvDuration("DESCRIPTOR_CACHE_TTL", 5*time.Minute)
+	descriptorCacheMaxEntries := config.GetEnvInt("DESCRIPTOR_CACHE_MAX_ENTRIES", 256)
+	descriptorCache := service.NewDescriptorCache(descriptorCacheTTL, descriptorCacheMaxEntries)
+	logger.Info("Descriptor cache initialized with labelTTL=%s, maxEntries=%d", descriptorCacheTTL, descriptorCacheMaxEntries)
+
 	// Get schema source mode from environment variable for schema service
 	schemaSourceMode := config.GetSchemaSourceMode("schema")
 	logger.Info("Schema source mode: %d", schemaSourceMode)
 
+	// Build the sources: compiled-in types and generated files in gen/jsonschema, BSR (cached)
+	// in the modes using it and, in image mode, the FileDescriptorSet images in DESCRIPTOR_IMAGE_PATH or, in
+	// snapshot mode, the snapshot bundles in SNAPSHOT_PATH
+	// protoFiles are the sources whose messages the proto files API lists
+	localDescriptors := service.NewRegistryDescriptorSource(nil)
+	protoFiles := []service.FileLister{localDescriptors}
+	descriptorSources := service.DescriptorSources{
+		Local: localDescriptors,
+	}
+	schemaSources := service.SchemaSources{
+		Local: service.NewLocalSchemaSource(filepath.Join(basePath, "gen", "jsonschema")),
+	}
+	// In the modes using BSR, BSR descriptors and schemas are persisted to BSR_CACHE_PATH,
+	// reused across restarts and served stale if BSR fails or does not answer within
+	// BSR_STALE_TIMEOUT
+	if validationSourceMode.UsesBSR() || schemaSourceMode.UsesBSR() {
+		bsrCachePath := config.GetEnv("BSR_CACHE_PATH", filepath.Join(basePath, "bsr-cache"))
+		bsrStaleTimeout := config.GetEnvDuration("BSR_STALE_TIMEOUT", 3*time.Second)
+		bsrCache, err := service.NewPersistentCache(bsrCachePath, bsrStaleTimeout)
+		if err != nil {
+			logger.Fatal("Failed to create persistent BSR cache: %v", err)
+		}
+		logger.Info("Persistent BSR cache initialized in %s with staleTimeout=%s", bsrCachePath, bsrStaleTimeout)
+
+		bsrSource := service.NewBSRDescriptorSource(bsrOrg, bsrModule, bsrToken)
+		persistentBSRSource := service.NewPersistentDescriptorSource(bsrSource, bsrCache, bsrSource.ModuleName())
+		descriptorSources.BSR = service.NewCachedDescriptorSource(persistentBSRSource, descriptorCache, bsrSource.ModuleName())
+		schemaSources.BSR = service.NewPersistentSchemaSource(service.NewBSRSchemaSource(bsrOrg, bsrModule, bsrToken, descriptorCacheTTL), bsrCache, bsrSource.ModuleName())
+	}
+	if validationSourceMode == config.ImageOnly || schemaSourceMode == config.ImageOnly {
+		imagePath := config.GetEnv("DESCRIPTOR_IMAGE_PATH", filepath.Join(basePath, "image.binpb"))
+		imageDescriptors, err := service.NewImageDescriptorSource(imagePath)
+		if err != nil {
+			logger.Fatal("Failed to load FileDescriptorSet images: %v", err)
+		}
+		descriptorSources.Image = imageDescriptors
+		protoFiles = append(protoFiles, imageDescriptors)
+	}
+	if validationSourceMode == config.Snapshot || schemaSourceMode == config.Snapshot {
+		snapshotPath := config.GetEnv("SNAPSHOT_PATH", filepath.Join(basePath, "snapshots"))
+		snapshots, err := service.NewSnapshotSource(snapshotPath)
+		if err != nil {
+			logger.Fatal("Failed to load snapshots: %v", err)
+		}
+		descriptorSources.Snapshot = snapshots
+		schemaSources.Snapshot = snapshots
+		protoFiles = append(protoFiles, snapshots)
+	}
+
+	// Compile the .proto files in PROTO_SOURCE_PATH in-process, if set, in place of the
+	// compiled-in types and generated files, and recompile them when they change
+	if protoSourcePath := config.GetEnv("PROTO_SOURCE_PATH", ""); protoSourcePath != "" {
+		includePaths := filepath.SplitList(config.GetEnv("PROTO_INCLUDE_PATH", ""))
+		compiledDescriptors, err := service.NewCompiledDescriptorSource(protoSourcePath, includePaths)
+		if err != nil {
+			logger.Fatal("Failed to compile protos: %v", err)
+		}
+		if watchInterval := config.GetEnvDuration("PROTO_WATCH_INTERVAL", time.Second); watchInterval > 0 {
+			go compiledDescriptors.Watch(context.Background(), watchInterval)
+		}
+		descriptorSources.Local = compiledDescriptors
+		protoFiles[0] = compiledDescriptors
+		schemaSources.Local = service.NewGeneratedSchemaSource(compiledDescriptors)
+	}
+
+	// Serve the descriptor sets registered at runtime first in every mode, persisted to
+	// DESCRIPTOR_REGISTRY_PATH across restarts
+	// The registration API is unauthenticated, so registering is off unless
+	// DESCRIPTOR_REGISTRATION_ENABLED is set; the registry then stays empty and in memory
+	descriptorRegistrationEnabled := config.GetEnvBool("DESCRIPTOR_REGISTRATION_ENABLED", false)
+	descriptorRegistryPath := ""
+	if descriptorRegistrationEnabled {
+		descriptorRegistryPath = config.GetEnv("DESCRIPTOR_REGISTRY_PATH", filepath.Join(basePath, "registered-descriptors"))
+	}
+	descriptorRegistry, err := service.NewDescriptorRegistry(descriptorRegistryPath)
+	if err != nil {
+		logger.Fatal("Failed to load registered descriptors: %v", err)
+	}
+	descriptorSources.Registered = descriptorRegistry
+	schemaSources.Registered = service.NewGeneratedSchemaSource(descriptorRegistry)
+	protoFiles = append(protoFiles, descriptorRegistry)
+	descriptorSource := service.NewDescriptorSourceForMode(validationSourceMode, descriptorSources)
+
+	// Build the schema source for the mode, then schemas generated from the descriptors of
+	// the same mode for any other message
+	generatedSchemas := service.NewGeneratedSchemaSource(service.NewDescriptorSourceForMode(schemaSourceMode, descriptorSources))
+	schemaSource := service.NewSchemaSourceForMode(schemaSourceMode, schemaSources, generatedSchemas)
+
 	// Initialize schema service
 	logger.Debug("Initializing schema service...")
-	schemaService := service.NewSchemaService(bsrOrg, bsrModule, basePath, schemaSourceMode)
-	logger.Info("Schema service initialized successfully with mode=%d", schemaSourceMode)
+	schemaService := service.NewSchemaService(schemaSource, protoFiles...)
+	logger.Info("Schema service initialized successfully with mode=%d, source=%s", schemaSourceMode, schemaSource.Name())
 
 	// Initialize schema handler
 	logger.Debug("Initializing schema handler...")
 	schemaHandler := handler.NewSchemaHandler(schemaService)
 	logger.Info("Schema handler initialized successfully")
 
-	// Get BSR token for validation service
-	bsrToken := config.GetEnv("BUF_TOKEN", "")
-	if bsrToken == "" {
-		logger.Warn("BUF_TOKEN is not set. BSR requests may fail for private repositories.")
-	} else {
-		logger.Debug("BUF_TOKEN is set (length: %d)", len(bsrToken))
-	}
-
-	// Get validation source mode from environment variable for validation service
-	validationSourceMode := config.GetSchemaSourceMode("validation")
-	logger.Info("Validation source mode: %d", validationSourceMode)
+	// Initialize descriptors handler
+	logger.Debug("Initializing descriptors handler...")
+	descriptorsHandler := handler.NewDescriptorsHandler(descriptorRegistry, descriptorRegistrationEnabled)
+	logger.Info("Descriptors handler initialized successfully with registrationEnabled=%v", descriptorRegistrationEnabled)
 
 	// Initialize validation service
 	logger.Debug("Initializing validation service...")
-	validationService := service.NewValidationService(validator, validationSourceMode, bsrOrg, bsrModule, bsrToken)
-	logger.Info("Validation service initialized successfully with mode=%d", validationSourceMode)
+	strictMode := config.GetEnvBool("VALIDATION_STRICT_MODE", false)
+	validationService := service.NewValidationService(validator, descriptorSource, strictMode)
+	logger.Info("Validation service initialized successfully with mode=%d, source=%s, strictMode=%v", validationSourceMode, descriptorSource.Name(), strictMode)
 
 	// Initialize validation handler
 	logger.Debug("Initializing validation handler...")
-	validationHandler := handler.NewValidationHandler(validationService)
-	logger.Info("Validation handler initialized successfully")
+	batchWorkers := config.GetEnvInt("VALIDATION_BATCH_WORKERS", 8)
+	maxBatchItems := config.GetEnvInt("VALIDATION_BATCH_MAX_ITEMS", 10000)
+	maxBatchBytes := int64(config.GetEnvInt("VALIDATION_BATCH_MAX_BYTES", 32<<20))
+	validationHandler := handler.NewValidationHandler(validationService, batchWorkers, maxBatchItems, maxBatchBytes)
+	logger.Info("Validation handler initialized successfully with batchWorkers=%d, maxBatchItems=%d, maxBatchBytes=%d", batchWorkers, maxBatchItems, maxBatchBytes)
 
 	// Initialize commits service
 	logger.Debug("Initializing commits service...")
@@ -153,6 +302,42 @@ func main() {
 	commitsHandler := handler.NewCommitsHandler(commitsService)
 	logger.Info("Commits handler initialized successfully")
 
+	// Initialize task service with the in-memory store
+	logger.Debug("Initializing task service...")
+	taskService := service.NewTaskService(service.NewMemoryTaskStore())
+	logger.Info("Task service initialized successfully with in-memory store")
+
+	// Initialize task handler
+	logger.Debug("Initializing task handler...")
+	taskHandler := handler.NewTaskHandler(taskService, validator)
+	logger.Info("Task handler initialized successfully")
+
+	// Validate every request (and optionally every response) with protovalidate
+	// The same interceptors serve the gRPC server and the Connect handlers on the HTTP port
+	// Requests are validated here, so the service implementations do not validate them again
+	validateResponses := config.GetEnvBool("GRPC_VALIDATE_RESPONSES", false)
+	var interceptorOpts []grpcserver.InterceptorOption
+	if validateResponses {
+		interceptorOpts = append(interceptorOpts, grpcserver.WithResponseValidation())
+	}
+	var skipMethods []string
+	for _, method := range strings.Split(config.GetEnv("GRPC_VALIDATE_SKIP_METHODS", ""), ",") {
+		if method = strings.TrimSpace(method); method != "" {
+			skipMethods = append(skipMethods, method)
+		}
+	}
+	if len(skipMethods) > 0 {
+		interceptorOpts = append(interceptorOpts, grpcserver.WithSkipMethods(skipMethods...))
+	}
+	unaryInterceptor := grpcserver.UnaryServerInterceptor(validator, interceptorOpts...)
+	streamInterceptor := grpcserver.StreamServerInterceptor(validator, interceptorOpts...)
+	logger.Debug("Initialized validation interceptors with validateResponses=%v, skipMethods=%v", validateResponses, skipMethods)
+
+	// Initialize gRPC service implementations
+	greeting := &greetingServer{}
+	validationServer := grpcserver.NewValidationServer(validationService, schemaService, commitsService, batchWorkers, maxBatchItems)
+	taskServer := grpcserver.NewTaskServer(taskService)
+
 	// Start gRPC server in a goroutine
 	go func() {
 		logger.Debug("Starting gRPC server on port :50051...")
@@ -161,10 +346,15 @@ func main() {
 			logger.Fatal("Failed to listen on port 50051: %v", err)
 		}
 
-		s := grpc.NewServer()
-		proto.RegisterGreetingServiceServer(s, &greetingServer{
-			validator: validator,
-		})
+		s := grpc.NewServer(
+			grpc.ChainUnaryInterceptor(unaryInterceptor),
+			grpc.ChainStreamInterceptor(streamInterceptor),
+		)
+
+		proto.RegisterGreetingServiceServer(s, greeting)
+		validationv1.RegisterValidationServiceServer(s, validationServer)
+		taskv1.RegisterTaskServiceServer(s, taskServer)
+		logger.Debug("Registered gRPC services: GreetingService, ValidationService, TaskService")
 
 		logger.Info("gRPC server starting on port :50051")
 		if err := s.Serve(lis); err != nil {
@@ -189,10 +379,47 @@ func main() {
 	http.HandleFunc("/api/v1/validate-proto", corsMiddleware(validationHandler.ValidateProto))
 	logger.Debug("Registered route: POST /api/v1/validate-proto")
 
+	// Register batch validation API route with CORS
+	http.HandleFunc("/api/v1/validate-proto/batch", corsMiddleware(validationHandler.ValidateBatch))
+	logger.Debug("Registered route: POST /api/v1/validate-proto/batch")
+
+	// Register NDJSON stream validation API route with CORS
+	http.HandleFunc("/api/v1/validate-proto/stream", corsMiddleware(validationHandler.ValidateStream))
+	logger.Debug("Registered route: POST /api/v1/validate-proto/stream")
+
+	// Register descriptor cache stats API route with CORS
+	http.HandleFunc("/api/v1/descriptor-cache/stats", corsMiddleware(validationHandler.GetCacheStats))
+	logger.Debug("Registered route: GET /api/v1/descriptor-cache/stats")
+
+	// Register descriptor registration API routes with CORS
+	http.HandleFunc("/api/v1/descriptors", corsMiddleware(descriptorsHandler.Descriptors))
+	logger.Debug("Registered route: GET, POST /api/v1/descriptors")
+	http.HandleFunc("/api/v1/descriptors/", corsMiddleware(descriptorsHandler.Descriptor))
+	logger.Debug("Registered route: GET, DELETE /api/v1/descriptors/{version}")
+
 	// Register commits API route with CORS
 	http.HandleFunc("/api/v1/commits", corsMiddleware(commitsHandler.GetCommits))
 	logger.Debug("Registered route: GET /api/v1/commits")
 
+	// Register task API routes with CORS
+	http.HandleFunc("/api/v1/tasks", corsMiddleware(taskHandler.Tasks))
+	logger.Debug("Registered route: GET, POST /api/v1/tasks")
+	http.HandleFunc("/api/v1/tasks/", corsMiddleware(taskHandler.Task))
+	logger.Debug("Registered route: GET, PATCH, DELETE /api/v1/tasks/{id}")
+
+	// Serve the gRPC services over the Connect, gRPC-Web and gRPC protocols for browser clients,
+	// gRPC clients reach them over cleartext HTTP/2 (h2c)
+	bridge := connectserver.NewBridge(unaryInterceptor)
+	connectPath, connectHandler := protoconnect.NewGreetingServiceHandler(connectserver.NewGreetingService(bridge, greeting))
+	http.HandleFunc(connectPath, corsMiddleware(connectHandler.ServeHTTP))
+	logger.Debug("Registered Connect route: POST %s", connectPath)
+	connectPath, connectHandler = validationv1connect.NewValidationServiceHandler(connectserver.NewValidationService(bridge, validationServer))
+	http.HandleFunc(connectPath, corsMiddleware(connectHandler.ServeHTTP))
+	logger.Debug("Registered Connect route: POST %s", connectPath)
+	connectPath, connectHandler = taskv1connect.NewTaskServiceHandler(connectserver.NewTaskService(bridge, taskServer))
+	http.HandleFunc(connectPath, corsMiddleware(connectHandler.ServeHTTP))
+	logger.Debug("Registered Connect route: POST %s", connectPath)
+
 	// Also register root route for convenience with CORS
 	http.HandleFunc("/", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/" {
@@ -210,10 +437,21 @@ func main() {
 	logger.Info("Schema API route available at http://localhost%s/api/v1/schema/{messageName}", port)
 	logger.Info("Proto files API route available at http://localhost%s/api/v1/proto-files", port)
 	logger.Info("Validation API route available at http://localhost%s/api/v1/validate-proto", port)
+	logger.Info("Batch validation API route available at http://localhost%s/api/v1/validate-proto/batch", port)
+	logger.Info("Stream validation API route available at http://localhost%s/api/v1/validate-proto/stream", port)
+	logger.Info("Descriptor cache stats route available at http://localhost%s/api/v1/descriptor-cache/stats", port)
+	logger.Info("Descriptors API routes available at http://localhost%s/api/v1/descriptors", port)
 	logger.Info("Commits API route available at http://localhost%s/api/v1/commits", port)
+	logger.Info("Task API routes available at http://localhost%s/api/v1/tasks", port)
+	logger.Info("Connect, gRPC-Web and gRPC services available at http://localhost%s/{package.Service}/{Method}", port)
 	logger.Info("Validation service started successfully")
 
-	if err := http.ListenAndServe(port, nil); err != nil {
+	// Accept cleartext HTTP/2 next to HTTP/1.1, native gRPC clients require it
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	server := &http.Server{Addr: port, Protocols: protocols}
+	if err := server.ListenAndServe(); err != nil {
 		logger.Fatal("Server failed to start: %v", err)
 	}
 }
@@ -0,0 +1,111 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: proto/greeting.proto
+
+package protoconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	strings "strings"
+	proto "validation-service/backend/proto"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// GreetingServiceName is the fully-qualified name of the GreetingService service.
+	GreetingServiceName = "proto.GreetingService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// GreetingServiceSayHelloProcedure is the fully-qualified name of the GreetingService's SayHello
+	// RPC.
+	GreetingServiceSayHelloProcedure = "/proto.GreetingService/SayHello"
+)
+
+// GreetingServiceClient is a client for the proto.GreetingService service.
+type GreetingServiceClient interface {
+	// SayHello takes a name and returns a greeting message
+	SayHello(context.Context, *connect.Request[proto.HelloRequest]) (*connect.Response[proto.HelloResponse], error)
+}
+
+// NewGreetingServiceClient constructs a client for the proto.GreetingService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewGreetingServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) GreetingServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	greetingServiceMethods := proto.File_proto_greeting_proto.Services().ByName("GreetingService").Methods()
+	return &greetingServiceClient{
+		sayHello: connect.NewClient[proto.HelloRequest, proto.HelloResponse](
+			httpClient,
+			baseURL+GreetingServiceSayHelloProcedure,
+			connect.WithSchema(greetingServiceMethods.ByName("SayHello")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// greetingServiceClient implements GreetingServiceClient.
+type greetingServiceClient struct {
+	sayHello *connect.Client[proto.HelloRequest, proto.HelloResponse]
+}
+
+// SayHello calls proto.GreetingService.SayHello.
+func (c *greetingServiceClient) SayHello(ctx context.Context, req *connect.Request[proto.HelloRequest]) (*connect.Response[proto.HelloResponse], error) {
+	return c.sayHello.CallUnary(ctx, req)
+}
+
+// GreetingServiceHandler is an implementation of the proto.GreetingService service.
+type GreetingServiceHandler interface {
+	// SayHello takes a name and returns a greeting message
+	SayHello(context.Context, *connect.Request[proto.HelloRequest]) (*connect.Response[proto.HelloResponse], error)
+}
+
+// NewGreetingServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewGreetingServiceHandler(svc GreetingServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	greetingServiceMethods := proto.File_proto_greeting_proto.Services().ByName("GreetingService").Methods()
+	greetingServiceSayHelloHandler := connect.NewUnaryHandler(
+		GreetingServiceSayHelloProcedure,
+		svc.SayHello,
+		connect.WithSchema(greetingServiceMethods.ByName("SayHello")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/proto.GreetingService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GreetingServiceSayHelloProcedure:
+			greetingServiceSayHelloHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedGreetingServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedGreetingServiceHandler struct{}
+
+func (UnimplementedGreetingServiceHandler) SayHello(context.Context, *connect.Request[proto.HelloRequest]) (*connect.Response[proto.HelloResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.GreetingService.SayHello is not implemented"))
+}
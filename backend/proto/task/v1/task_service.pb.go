@@ -0,0 +1,532 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: proto/task/v1/task_service.proto
+
+package taskv1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+	proto "validation-service/backend/proto"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TaskRecord is a stored task
+type TaskRecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is assigned by the service when the task is created
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// task holds the task itself, its timestamp is updated on every status change
+	Task *proto.Task `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	// comment is the comment of the latest status update, if any
+	Comment string `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	// create_time is when the task was created
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// update_time is when the task was last updated
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskRecord) Reset() {
+	*x = TaskRecord{}
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskRecord) ProtoMessage() {}
+
+func (x *TaskRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskRecord.ProtoReflect.Descriptor instead.
+func (*TaskRecord) Descriptor() ([]byte, []int) {
+	return file_proto_task_v1_task_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TaskRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaskRecord) GetTask() *proto.Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TaskRecord) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *TaskRecord) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *TaskRecord) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+// CreateTaskRequest contains the task to create
+type CreateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *proto.Task            `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_v1_task_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateTaskRequest) GetTask() *proto.Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// GetTaskRequest identifies the task to return
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_v1_task_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ListTasksRequest contains the filter and page to list
+type ListTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// page_size is the maximum number of tasks to return, defaults to 50
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of a previous response
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// status only lists tasks with this status, TASK_STATUS_UNSPECIFIED lists all tasks
+	Status        proto.TaskStatus `protobuf:"varint,3,opt,name=status,proto3,enum=proto.TaskStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_v1_task_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetStatus() proto.TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return proto.TaskStatus(0)
+}
+
+// ListTasksResponse contains a page of tasks
+type ListTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*TaskRecord          `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// next_page_token is empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_v1_task_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListTasksResponse) GetTasks() []*TaskRecord {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// UpdateTaskStatusRequest identifies a task and its new status
+type UpdateTaskStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Update        *proto.UpdateTask      `protobuf:"bytes,2,opt,name=update,proto3" json:"update,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskStatusRequest) Reset() {
+	*x = UpdateTaskStatusRequest{}
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskStatusRequest) ProtoMessage() {}
+
+func (x *UpdateTaskStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_v1_task_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateTaskStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTaskStatusRequest) GetUpdate() *proto.UpdateTask {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+// DeleteTaskResponse contains the deleted task
+type DeleteTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *TaskRecord            `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskResponse) Reset() {
+	*x = DeleteTaskResponse{}
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskResponse) ProtoMessage() {}
+
+func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_v1_task_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_v1_task_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteTaskResponse) GetTask() *TaskRecord {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+var File_proto_task_v1_task_service_proto protoreflect.FileDescriptor
+
+const file_proto_task_v1_task_service_proto_rawDesc = "" +
+	"\n" +
+	" proto/task/v1/task_service.proto\x12\atask.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17proto/delete_task.proto\x1a\x10proto/task.proto\"\xd1\x01\n" +
+	"\n" +
+	"TaskRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\x04task\x18\x02 \x01(\v2\v.proto.TaskR\x04task\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\x12;\n" +
+	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\"<\n" +
+	"\x11CreateTaskRequest\x12'\n" +
+	"\x04task\x18\x01 \x01(\v2\v.proto.TaskB\x06\xbaH\x03\xc8\x01\x01R\x04task\",\n" +
+	"\x0eGetTaskRequest\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"\xbaH\a\xc8\x01\x01r\x02\x18dR\x02id\"\x8e\x01\n" +
+	"\x10ListTasksRequest\x12&\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x123\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x11.proto.TaskStatusB\b\xbaH\x05\x82\x01\x02\x10\x01R\x06status\"f\n" +
+	"\x11ListTasksResponse\x12)\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x13.task.v1.TaskRecordR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"h\n" +
+	"\x17UpdateTaskStatusRequest\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"\xbaH\a\xc8\x01\x01r\x02\x18dR\x02id\x121\n" +
+	"\x06update\x18\x02 \x01(\v2\x11.proto.UpdateTaskB\x06\xbaH\x03\xc8\x01\x01R\x06update\"=\n" +
+	"\x12DeleteTaskResponse\x12'\n" +
+	"\x04task\x18\x01 \x01(\v2\x13.task.v1.TaskRecordR\x04task2\xd2\x02\n" +
+	"\vTaskService\x12=\n" +
+	"\n" +
+	"CreateTask\x12\x1a.task.v1.CreateTaskRequest\x1a\x13.task.v1.TaskRecord\x127\n" +
+	"\aGetTask\x12\x17.task.v1.GetTaskRequest\x1a\x13.task.v1.TaskRecord\x12B\n" +
+	"\tListTasks\x12\x19.task.v1.ListTasksRequest\x1a\x1a.task.v1.ListTasksResponse\x12I\n" +
+	"\x10UpdateTaskStatus\x12 .task.v1.UpdateTaskStatusRequest\x1a\x13.task.v1.TaskRecord\x12<\n" +
+	"\n" +
+	"DeleteTask\x12\x11.proto.DeleteTask\x1a\x1b.task.v1.DeleteTaskResponseB1Z/validation-service/backend/proto/task/v1;taskv1b\x06proto3"
+
+var (
+	file_proto_task_v1_task_service_proto_rawDescOnce sync.Once
+	file_proto_task_v1_task_service_proto_rawDescData []byte
+)
+
+func file_proto_task_v1_task_service_proto_rawDescGZIP() []byte {
+	file_proto_task_v1_task_service_proto_rawDescOnce.Do(func() {
+		file_proto_task_v1_task_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_task_v1_task_service_proto_rawDesc), len(file_proto_task_v1_task_service_proto_rawDesc)))
+	})
+	return file_proto_task_v1_task_service_proto_rawDescData
+}
+
+var file_proto_task_v1_task_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_task_v1_task_service_proto_goTypes = []any{
+	(*TaskRecord)(nil),              // 0: task.v1.TaskRecord
+	(*CreateTaskRequest)(nil),       // 1: task.v1.CreateTaskRequest
+	(*GetTaskRequest)(nil),          // 2: task.v1.GetTaskRequest
+	(*ListTasksRequest)(nil),        // 3: task.v1.ListTasksRequest
+	(*ListTasksResponse)(nil),       // 4: task.v1.ListTasksResponse
+	(*UpdateTaskStatusRequest)(nil), // 5: task.v1.UpdateTaskStatusRequest
+	(*DeleteTaskResponse)(nil),      // 6: task.v1.DeleteTaskResponse
+	(*proto.Task)(nil),              // 7: proto.Task
+	(*timestamppb.Timestamp)(nil),   // 8: google.protobuf.Timestamp
+	(proto.TaskStatus)(0),           // 9: proto.TaskStatus
+	(*proto.UpdateTask)(nil),        // 10: proto.UpdateTask
+	(*proto.DeleteTask)(nil),        // 11: proto.DeleteTask
+}
+var file_proto_task_v1_task_service_proto_depIdxs = []int32{
+	7,  // 0: task.v1.TaskRecord.task:type_name -> proto.Task
+	8,  // 1: task.v1.TaskRecord.create_time:type_name -> google.protobuf.Timestamp
+	8,  // 2: task.v1.TaskRecord.update_time:type_name -> google.protobuf.Timestamp
+	7,  // 3: task.v1.CreateTaskRequest.task:type_name -> proto.Task
+	9,  // 4: task.v1.ListTasksRequest.status:type_name -> proto.TaskStatus
+	0,  // 5: task.v1.ListTasksResponse.tasks:type_name -> task.v1.TaskRecord
+	10, // 6: task.v1.UpdateTaskStatusRequest.update:type_name -> proto.UpdateTask
+	0,  // 7: task.v1.DeleteTaskResponse.task:type_name -> task.v1.TaskRecord
+	1,  // 8: task.v1.TaskService.CreateTask:input_type -> task.v1.CreateTaskRequest
+	2,  // 9: task.v1.TaskService.GetTask:input_type -> task.v1.GetTaskRequest
+	3,  // 10: task.v1.TaskService.ListTasks:input_type -> task.v1.ListTasksRequest
+	5,  // 11: task.v1.TaskService.UpdateTaskStatus:input_type -> task.v1.UpdateTaskStatusRequest
+	11, // 12: task.v1.TaskService.DeleteTask:input_type -> proto.DeleteTask
+	0,  // 13: task.v1.TaskService.CreateTask:output_type -> task.v1.TaskRecord
+	0,  // 14: task.v1.TaskService.GetTask:output_type -> task.v1.TaskRecord
+	4,  // 15: task.v1.TaskService.ListTasks:output_type -> task.v1.ListTasksResponse
+	0,  // 16: task.v1.TaskService.UpdateTaskStatus:output_type -> task.v1.TaskRecord
+	6,  // 17: task.v1.TaskService.DeleteTask:output_type -> task.v1.DeleteTaskResponse
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_proto_task_v1_task_service_proto_init() }
+func file_proto_task_v1_task_service_proto_init() {
+	if File_proto_task_v1_task_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_task_v1_task_service_proto_rawDesc), len(file_proto_task_v1_task_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_task_v1_task_service_proto_goTypes,
+		DependencyIndexes: file_proto_task_v1_task_service_proto_depIdxs,
+		MessageInfos:      file_proto_task_v1_task_service_proto_msgTypes,
+	}.Build()
+	File_proto_task_v1_task_service_proto = out.File
+	file_proto_task_v1_task_service_proto_goTypes = nil
+	file_proto_task_v1_task_service_proto_depIdxs = nil
+}
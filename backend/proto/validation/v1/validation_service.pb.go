@@ -0,0 +1,1550 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: proto/validation/v1/validation_service.proto
+
+package validationv1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FieldNames is the naming of JSON Schema properties
+type FieldNames int32
+
+const (
+	FieldNames_FIELD_NAMES_UNSPECIFIED FieldNames = 0
+	// FIELD_NAMES_PROTO uses proto field names, e.g. "country_code"
+	FieldNames_FIELD_NAMES_PROTO FieldNames = 1
+	// FIELD_NAMES_JSON uses JSON names, e.g. "countryCode"
+	FieldNames_FIELD_NAMES_JSON FieldNames = 2
+)
+
+// Enum value maps for FieldNames.
+var (
+	FieldNames_name = map[int32]string{
+		0: "FIELD_NAMES_UNSPECIFIED",
+		1: "FIELD_NAMES_PROTO",
+		2: "FIELD_NAMES_JSON",
+	}
+	FieldNames_value = map[string]int32{
+		"FIELD_NAMES_UNSPECIFIED": 0,
+		"FIELD_NAMES_PROTO":       1,
+		"FIELD_NAMES_JSON":        2,
+	}
+)
+
+func (x FieldNames) Enum() *FieldNames {
+	p := new(FieldNames)
+	*p = x
+	return p
+}
+
+func (x FieldNames) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FieldNames) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_validation_v1_validation_service_proto_enumTypes[0].Descriptor()
+}
+
+func (FieldNames) Type() protoreflect.EnumType {
+	return &file_proto_validation_v1_validation_service_proto_enumTypes[0]
+}
+
+func (x FieldNames) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FieldNames.Descriptor instead.
+func (FieldNames) EnumDescriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{0}
+}
+
+// ValidateProtoRequest contains a payload and the schema to validate it against
+type ValidateProtoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// schema_name is the fully qualified message name, e.g. "proto.Task"
+	SchemaName string `protobuf:"bytes,1,opt,name=schema_name,json=schemaName,proto3" json:"schema_name,omitempty"`
+	// payload in one of the supported encodings
+	//
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ValidateProtoRequest_PayloadJson
+	//	*ValidateProtoRequest_PayloadBinary
+	//	*ValidateProtoRequest_PayloadText
+	Payload isValidateProtoRequest_Payload `protobuf_oneof:"payload"`
+	// commit is the BSR commit ID or label to validate against, defaults to "main"
+	// A specific commit fails with FAILED_PRECONDITION if the server only has local definitions
+	Commit string `protobuf:"bytes,5,opt,name=commit,proto3" json:"commit,omitempty"`
+	// strict reports unknown fields as violations, defaults to the server setting
+	Strict        *bool `protobuf:"varint,6,opt,name=strict,proto3,oneof" json:"strict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateProtoRequest) Reset() {
+	*x = ValidateProtoRequest{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateProtoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateProtoRequest) ProtoMessage() {}
+
+func (x *ValidateProtoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateProtoRequest.ProtoReflect.Descriptor instead.
+func (*ValidateProtoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ValidateProtoRequest) GetSchemaName() string {
+	if x != nil {
+		return x.SchemaName
+	}
+	return ""
+}
+
+func (x *ValidateProtoRequest) GetPayload() isValidateProtoRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ValidateProtoRequest) GetPayloadJson() string {
+	if x != nil {
+		if x, ok := x.Payload.(*ValidateProtoRequest_PayloadJson); ok {
+			return x.PayloadJson
+		}
+	}
+	return ""
+}
+
+func (x *ValidateProtoRequest) GetPayloadBinary() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ValidateProtoRequest_PayloadBinary); ok {
+			return x.PayloadBinary
+		}
+	}
+	return nil
+}
+
+func (x *ValidateProtoRequest) GetPayloadText() string {
+	if x != nil {
+		if x, ok := x.Payload.(*ValidateProtoRequest_PayloadText); ok {
+			return x.PayloadText
+		}
+	}
+	return ""
+}
+
+func (x *ValidateProtoRequest) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *ValidateProtoRequest) GetStrict() bool {
+	if x != nil && x.Strict != nil {
+		return *x.Strict
+	}
+	return false
+}
+
+type isValidateProtoRequest_Payload interface {
+	isValidateProtoRequest_Payload()
+}
+
+type ValidateProtoRequest_PayloadJson struct {
+	// payload_json is the protobuf JSON mapping of the message
+	PayloadJson string `protobuf:"bytes,2,opt,name=payload_json,json=payloadJson,proto3,oneof"`
+}
+
+type ValidateProtoRequest_PayloadBinary struct {
+	// payload_binary is the protobuf wire format of the message
+	PayloadBinary []byte `protobuf:"bytes,3,opt,name=payload_binary,json=payloadBinary,proto3,oneof"`
+}
+
+type ValidateProtoRequest_PayloadText struct {
+	// payload_text is the protobuf text format of the message
+	PayloadText string `protobuf:"bytes,4,opt,name=payload_text,json=payloadText,proto3,oneof"`
+}
+
+func (*ValidateProtoRequest_PayloadJson) isValidateProtoRequest_Payload() {}
+
+func (*ValidateProtoRequest_PayloadBinary) isValidateProtoRequest_Payload() {}
+
+func (*ValidateProtoRequest_PayloadText) isValidateProtoRequest_Payload() {}
+
+// ValidateProtoResponse contains the validation outcome
+type ValidateProtoResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Errors  []*ValidationError     `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	// resolution describes the definitions the payload was validated against
+	Resolution    *Resolution `protobuf:"bytes,3,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateProtoResponse) Reset() {
+	*x = ValidateProtoResponse{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateProtoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateProtoResponse) ProtoMessage() {}
+
+func (x *ValidateProtoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateProtoResponse.ProtoReflect.Descriptor instead.
+func (*ValidateProtoResponse) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ValidateProtoResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ValidateProtoResponse) GetErrors() []*ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ValidateProtoResponse) GetResolution() *Resolution {
+	if x != nil {
+		return x.Resolution
+	}
+	return nil
+}
+
+// Resolution describes where a descriptor or schema came from
+type Resolution struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// resolved_commit is the concrete commit the requested version resolved to
+	// Local sources report "local"; empty if a BSR label could not be resolved to a commit
+	ResolvedCommit string `protobuf:"bytes,1,opt,name=resolved_commit,json=resolvedCommit,proto3" json:"resolved_commit,omitempty"`
+	// source is the source that satisfied the lookup: "local", "bsr", "cache", "generated",
+	// "image", "snapshot", "compiled" or "registered"
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// digest is the sha256 digest of the definitions (descriptors) or document (schema) used,
+	// computed by this service; it is not the BSR module digest
+	Digest string `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	// module_digest is the BSR module digest of resolved_commit as in buf.lock, e.g. "b5:...",
+	// empty for local sources or if it could not be looked up
+	ModuleDigest string `protobuf:"bytes,4,opt,name=module_digest,json=moduleDigest,proto3" json:"module_digest,omitempty"`
+	// stale is set when BSR failed or timed out and the last good copy, from the persistent
+	// cache, was served; resolved_commit is the commit the label last resolved to
+	Stale         bool `protobuf:"varint,5,opt,name=stale,proto3" json:"stale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Resolution) Reset() {
+	*x = Resolution{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Resolution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resolution) ProtoMessage() {}
+
+func (x *Resolution) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resolution.ProtoReflect.Descriptor instead.
+func (*Resolution) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Resolution) GetResolvedCommit() string {
+	if x != nil {
+		return x.ResolvedCommit
+	}
+	return ""
+}
+
+func (x *Resolution) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Resolution) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *Resolution) GetModuleDigest() string {
+	if x != nil {
+		return x.ModuleDigest
+	}
+	return ""
+}
+
+func (x *Resolution) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+// ValidationError is a single violation, mirroring the errors of the HTTP API
+type ValidationError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// friendly is a human-readable message
+	Friendly string `protobuf:"bytes,1,opt,name=friendly,proto3" json:"friendly,omitempty"`
+	// technical is the original technical error
+	Technical string `protobuf:"bytes,2,opt,name=technical,proto3" json:"technical,omitempty"`
+	// field is the field path using proto names, e.g. "contact_info.country_code"
+	Field string `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
+	// json_field is the field path using JSON names, e.g. "contactInfo.countryCode"
+	JsonField string `protobuf:"bytes,4,opt,name=json_field,json=jsonField,proto3" json:"json_field,omitempty"`
+	// pointer is the RFC 6901 JSON Pointer into the payload, "" is the root
+	// It is unset if the error has no location, e.g. a payload that cannot be decoded at all
+	Pointer *string `protobuf:"bytes,5,opt,name=pointer,proto3,oneof" json:"pointer,omitempty"`
+	// rule_id is the violated rule, e.g. "string.min_len"
+	RuleId string `protobuf:"bytes,6,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// constraint_id is the custom CEL constraint id, if any
+	ConstraintId string `protobuf:"bytes,7,opt,name=constraint_id,json=constraintId,proto3" json:"constraint_id,omitempty"`
+	// value is the offending value, if any
+	Value *structpb.Value `protobuf:"bytes,8,opt,name=value,proto3" json:"value,omitempty"`
+	// for_key is true if the violation is for a map key rather than its value
+	ForKey        bool `protobuf:"varint,9,opt,name=for_key,json=forKey,proto3" json:"for_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationError) Reset() {
+	*x = ValidationError{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationError) ProtoMessage() {}
+
+func (x *ValidationError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationError.ProtoReflect.Descriptor instead.
+func (*ValidationError) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ValidationError) GetFriendly() string {
+	if x != nil {
+		return x.Friendly
+	}
+	return ""
+}
+
+func (x *ValidationError) GetTechnical() string {
+	if x != nil {
+		return x.Technical
+	}
+	return ""
+}
+
+func (x *ValidationError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ValidationError) GetJsonField() string {
+	if x != nil {
+		return x.JsonField
+	}
+	return ""
+}
+
+func (x *ValidationError) GetPointer() string {
+	if x != nil && x.Pointer != nil {
+		return *x.Pointer
+	}
+	return ""
+}
+
+func (x *ValidationError) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *ValidationError) GetConstraintId() string {
+	if x != nil {
+		return x.ConstraintId
+	}
+	return ""
+}
+
+func (x *ValidationError) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ValidationError) GetForKey() bool {
+	if x != nil {
+		return x.ForKey
+	}
+	return false
+}
+
+// ValidateBatchRequest contains the payloads to validate
+// schema_name, commit and strict are defaults for items that do not set them
+type ValidateBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaName    string                 `protobuf:"bytes,1,opt,name=schema_name,json=schemaName,proto3" json:"schema_name,omitempty"`
+	Commit        string                 `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	Strict        *bool                  `protobuf:"varint,3,opt,name=strict,proto3,oneof" json:"strict,omitempty"`
+	Items         []*ValidateBatchItem   `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateBatchRequest) Reset() {
+	*x = ValidateBatchRequest{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateBatchRequest) ProtoMessage() {}
+
+func (x *ValidateBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateBatchRequest.ProtoReflect.Descriptor instead.
+func (*ValidateBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ValidateBatchRequest) GetSchemaName() string {
+	if x != nil {
+		return x.SchemaName
+	}
+	return ""
+}
+
+func (x *ValidateBatchRequest) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *ValidateBatchRequest) GetStrict() bool {
+	if x != nil && x.Strict != nil {
+		return *x.Strict
+	}
+	return false
+}
+
+func (x *ValidateBatchRequest) GetItems() []*ValidateBatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// ValidateBatchItem is a single payload of a batch
+type ValidateBatchItem struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	SchemaName string                 `protobuf:"bytes,1,opt,name=schema_name,json=schemaName,proto3" json:"schema_name,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ValidateBatchItem_PayloadJson
+	//	*ValidateBatchItem_PayloadBinary
+	//	*ValidateBatchItem_PayloadText
+	Payload       isValidateBatchItem_Payload `protobuf_oneof:"payload"`
+	Commit        string                      `protobuf:"bytes,5,opt,name=commit,proto3" json:"commit,omitempty"`
+	Strict        *bool                       `protobuf:"varint,6,opt,name=strict,proto3,oneof" json:"strict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateBatchItem) Reset() {
+	*x = ValidateBatchItem{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateBatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateBatchItem) ProtoMessage() {}
+
+func (x *ValidateBatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateBatchItem.ProtoReflect.Descriptor instead.
+func (*ValidateBatchItem) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ValidateBatchItem) GetSchemaName() string {
+	if x != nil {
+		return x.SchemaName
+	}
+	return ""
+}
+
+func (x *ValidateBatchItem) GetPayload() isValidateBatchItem_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ValidateBatchItem) GetPayloadJson() string {
+	if x != nil {
+		if x, ok := x.Payload.(*ValidateBatchItem_PayloadJson); ok {
+			return x.PayloadJson
+		}
+	}
+	return ""
+}
+
+func (x *ValidateBatchItem) GetPayloadBinary() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ValidateBatchItem_PayloadBinary); ok {
+			return x.PayloadBinary
+		}
+	}
+	return nil
+}
+
+func (x *ValidateBatchItem) GetPayloadText() string {
+	if x != nil {
+		if x, ok := x.Payload.(*ValidateBatchItem_PayloadText); ok {
+			return x.PayloadText
+		}
+	}
+	return ""
+}
+
+func (x *ValidateBatchItem) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *ValidateBatchItem) GetStrict() bool {
+	if x != nil && x.Strict != nil {
+		return *x.Strict
+	}
+	return false
+}
+
+type isValidateBatchItem_Payload interface {
+	isValidateBatchItem_Payload()
+}
+
+type ValidateBatchItem_PayloadJson struct {
+	PayloadJson string `protobuf:"bytes,2,opt,name=payload_json,json=payloadJson,proto3,oneof"`
+}
+
+type ValidateBatchItem_PayloadBinary struct {
+	PayloadBinary []byte `protobuf:"bytes,3,opt,name=payload_binary,json=payloadBinary,proto3,oneof"`
+}
+
+type ValidateBatchItem_PayloadText struct {
+	PayloadText string `protobuf:"bytes,4,opt,name=payload_text,json=payloadText,proto3,oneof"`
+}
+
+func (*ValidateBatchItem_PayloadJson) isValidateBatchItem_Payload() {}
+
+func (*ValidateBatchItem_PayloadBinary) isValidateBatchItem_Payload() {}
+
+func (*ValidateBatchItem_PayloadText) isValidateBatchItem_Payload() {}
+
+// ValidateBatchResponse contains one result per item, in request order
+type ValidateBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// success is true only if every item is valid
+	Success       bool           `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Total         int32          `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Valid         int32          `protobuf:"varint,3,opt,name=valid,proto3" json:"valid,omitempty"`
+	Invalid       int32          `protobuf:"varint,4,opt,name=invalid,proto3" json:"invalid,omitempty"`
+	Results       []*BatchResult `protobuf:"bytes,5,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateBatchResponse) Reset() {
+	*x = ValidateBatchResponse{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateBatchResponse) ProtoMessage() {}
+
+func (x *ValidateBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateBatchResponse.ProtoReflect.Descriptor instead.
+func (*ValidateBatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ValidateBatchResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ValidateBatchResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ValidateBatchResponse) GetValid() int32 {
+	if x != nil {
+		return x.Valid
+	}
+	return 0
+}
+
+func (x *ValidateBatchResponse) GetInvalid() int32 {
+	if x != nil {
+		return x.Invalid
+	}
+	return 0
+}
+
+func (x *ValidateBatchResponse) GetResults() []*BatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// BatchResult is the validation outcome of a single batch item
+type BatchResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Index   int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Success bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Errors  []*ValidationError     `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	// error is set if the item could not be validated (unknown schema, malformed payload, etc.)
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// resolution is unset if the item's schema could not be resolved
+	Resolution    *Resolution `protobuf:"bytes,5,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchResult) Reset() {
+	*x = BatchResult{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResult) ProtoMessage() {}
+
+func (x *BatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResult.ProtoReflect.Descriptor instead.
+func (*BatchResult) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BatchResult) GetErrors() []*ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *BatchResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchResult) GetResolution() *Resolution {
+	if x != nil {
+		return x.Resolution
+	}
+	return nil
+}
+
+// GetSchemaRequest identifies the message to return the JSON Schema for
+type GetSchemaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// message_name is the fully qualified message name, e.g. "proto.Task"
+	MessageName string `protobuf:"bytes,1,opt,name=message_name,json=messageName,proto3" json:"message_name,omitempty"`
+	// commit is the BSR commit ID or label the schema is generated from, defaults to "main"
+	// Local schemas are served as version "local"
+	Commit string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	// field_names selects proto field names (the default) or JSON names for properties
+	FieldNames FieldNames `protobuf:"varint,3,opt,name=field_names,json=fieldNames,proto3,enum=validation.v1.FieldNames" json:"field_names,omitempty"`
+	// strict selects the variant that only accepts the canonical JSON encoding of each field
+	Strict bool `protobuf:"varint,4,opt,name=strict,proto3" json:"strict,omitempty"`
+	// bundle selects the variant with nested messages in "$defs" (the default) rather than
+	// "$ref"s to their own files
+	Bundle *bool `protobuf:"varint,5,opt,name=bundle,proto3,oneof" json:"bundle,omitempty"`
+	// resolve_refs bundles the "$ref"s of an unbundled variant on demand
+	ResolveRefs   bool `protobuf:"varint,6,opt,name=resolve_refs,json=resolveRefs,proto3" json:"resolve_refs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSchemaRequest) Reset() {
+	*x = GetSchemaRequest{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaRequest) ProtoMessage() {}
+
+func (x *GetSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaRequest.ProtoReflect.Descriptor instead.
+func (*GetSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetSchemaRequest) GetMessageName() string {
+	if x != nil {
+		return x.MessageName
+	}
+	return ""
+}
+
+func (x *GetSchemaRequest) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *GetSchemaRequest) GetFieldNames() FieldNames {
+	if x != nil {
+		return x.FieldNames
+	}
+	return FieldNames_FIELD_NAMES_UNSPECIFIED
+}
+
+func (x *GetSchemaRequest) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+func (x *GetSchemaRequest) GetBundle() bool {
+	if x != nil && x.Bundle != nil {
+		return *x.Bundle
+	}
+	return false
+}
+
+func (x *GetSchemaRequest) GetResolveRefs() bool {
+	if x != nil {
+		return x.ResolveRefs
+	}
+	return false
+}
+
+// GetSchemaResponse contains the JSON Schema document
+type GetSchemaResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	JsonSchema string                 `protobuf:"bytes,1,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"`
+	// resolution describes where the document was retrieved
+	Resolution    *Resolution `protobuf:"bytes,2,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSchemaResponse) Reset() {
+	*x = GetSchemaResponse{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaResponse) ProtoMessage() {}
+
+func (x *GetSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaResponse.ProtoReflect.Descriptor instead.
+func (*GetSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetSchemaResponse) GetJsonSchema() string {
+	if x != nil {
+		return x.JsonSchema
+	}
+	return ""
+}
+
+func (x *GetSchemaResponse) GetResolution() *Resolution {
+	if x != nil {
+		return x.Resolution
+	}
+	return nil
+}
+
+// ListMessagesRequest has no parameters
+type ListMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessagesRequest) Reset() {
+	*x = ListMessagesRequest{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesRequest) ProtoMessage() {}
+
+func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{10}
+}
+
+// ListMessagesResponse contains the messages that can be validated
+type ListMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*MessageInfo         `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessagesResponse) Reset() {
+	*x = ListMessagesResponse{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesResponse) ProtoMessage() {}
+
+func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListMessagesResponse) GetMessages() []*MessageInfo {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// MessageInfo describes a message that can be validated
+type MessageInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the readable message name, e.g. "Complex Order"
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// full_name is the fully qualified message name, e.g. "proto.ComplexOrder"
+	FullName string `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	// version is the version to request the message with, e.g. a version registered at
+	// runtime, empty for the default version
+	Version       string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageInfo) Reset() {
+	*x = MessageInfo{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageInfo) ProtoMessage() {}
+
+func (x *MessageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageInfo.ProtoReflect.Descriptor instead.
+func (*MessageInfo) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MessageInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MessageInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MessageInfo) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *MessageInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// ListCommitsRequest selects a page of the commit history of a label
+type ListCommitsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// label defaults to "main"
+	Label string `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	// page_size defaults to 26
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommitsRequest) Reset() {
+	*x = ListCommitsRequest{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommitsRequest) ProtoMessage() {}
+
+func (x *ListCommitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommitsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommitsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListCommitsRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *ListCommitsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCommitsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListCommitsResponse contains a page of commits
+type ListCommitsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Commits       []*CommitInfo          `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommitsResponse) Reset() {
+	*x = ListCommitsResponse{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommitsResponse) ProtoMessage() {}
+
+func (x *ListCommitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommitsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommitsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListCommitsResponse) GetCommits() []*CommitInfo {
+	if x != nil {
+		return x.Commits
+	}
+	return nil
+}
+
+func (x *ListCommitsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// CommitInfo describes a BSR commit
+type CommitInfo struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreateTime  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	DigestType  string                 `protobuf:"bytes,3,opt,name=digest_type,json=digestType,proto3" json:"digest_type,omitempty"`
+	DigestValue string                 `protobuf:"bytes,4,opt,name=digest_value,json=digestValue,proto3" json:"digest_value,omitempty"`
+	// check_status is the BSR policy check status of the commit
+	CheckStatus   string `protobuf:"bytes,5,opt,name=check_status,json=checkStatus,proto3" json:"check_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitInfo) Reset() {
+	*x = CommitInfo{}
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitInfo) ProtoMessage() {}
+
+func (x *CommitInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_v1_validation_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitInfo.ProtoReflect.Descriptor instead.
+func (*CommitInfo) Descriptor() ([]byte, []int) {
+	return file_proto_validation_v1_validation_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CommitInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CommitInfo) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *CommitInfo) GetDigestType() string {
+	if x != nil {
+		return x.DigestType
+	}
+	return ""
+}
+
+func (x *CommitInfo) GetDigestValue() string {
+	if x != nil {
+		return x.DigestValue
+	}
+	return ""
+}
+
+func (x *CommitInfo) GetCheckStatus() string {
+	if x != nil {
+		return x.CheckStatus
+	}
+	return ""
+}
+
+var File_proto_validation_v1_validation_service_proto protoreflect.FileDescriptor
+
+const file_proto_validation_v1_validation_service_proto_rawDesc = "" +
+	"\n" +
+	",proto/validation/v1/validation_service.proto\x12\rvalidation.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x84\x02\n" +
+	"\x14ValidateProtoRequest\x12'\n" +
+	"\vschema_name\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"schemaName\x12#\n" +
+	"\fpayload_json\x18\x02 \x01(\tH\x00R\vpayloadJson\x12'\n" +
+	"\x0epayload_binary\x18\x03 \x01(\fH\x00R\rpayloadBinary\x12#\n" +
+	"\fpayload_text\x18\x04 \x01(\tH\x00R\vpayloadText\x12\x16\n" +
+	"\x06commit\x18\x05 \x01(\tR\x06commit\x12\x1b\n" +
+	"\x06strict\x18\x06 \x01(\bH\x01R\x06strict\x88\x01\x01B\x10\n" +
+	"\apayload\x12\x05\xbaH\x02\b\x01B\t\n" +
+	"\a_strict\"\xa4\x01\n" +
+	"\x15ValidateProtoResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x126\n" +
+	"\x06errors\x18\x02 \x03(\v2\x1e.validation.v1.ValidationErrorR\x06errors\x129\n" +
+	"\n" +
+	"resolution\x18\x03 \x01(\v2\x19.validation.v1.ResolutionR\n" +
+	"resolution\"\xa0\x01\n" +
+	"\n" +
+	"Resolution\x12'\n" +
+	"\x0fresolved_commit\x18\x01 \x01(\tR\x0eresolvedCommit\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x16\n" +
+	"\x06digest\x18\x03 \x01(\tR\x06digest\x12#\n" +
+	"\rmodule_digest\x18\x04 \x01(\tR\fmoduleDigest\x12\x14\n" +
+	"\x05stale\x18\x05 \x01(\bR\x05stale\"\xb0\x02\n" +
+	"\x0fValidationError\x12\x1a\n" +
+	"\bfriendly\x18\x01 \x01(\tR\bfriendly\x12\x1c\n" +
+	"\ttechnical\x18\x02 \x01(\tR\ttechnical\x12\x14\n" +
+	"\x05field\x18\x03 \x01(\tR\x05field\x12\x1d\n" +
+	"\n" +
+	"json_field\x18\x04 \x01(\tR\tjsonField\x12\x1d\n" +
+	"\apointer\x18\x05 \x01(\tH\x00R\apointer\x88\x01\x01\x12\x17\n" +
+	"\arule_id\x18\x06 \x01(\tR\x06ruleId\x12#\n" +
+	"\rconstraint_id\x18\a \x01(\tR\fconstraintId\x12,\n" +
+	"\x05value\x18\b \x01(\v2\x16.google.protobuf.ValueR\x05value\x12\x17\n" +
+	"\afor_key\x18\t \x01(\bR\x06forKeyB\n" +
+	"\n" +
+	"\b_pointer\"\xb9\x01\n" +
+	"\x14ValidateBatchRequest\x12\x1f\n" +
+	"\vschema_name\x18\x01 \x01(\tR\n" +
+	"schemaName\x12\x16\n" +
+	"\x06commit\x18\x02 \x01(\tR\x06commit\x12\x1b\n" +
+	"\x06strict\x18\x03 \x01(\bH\x00R\x06strict\x88\x01\x01\x12@\n" +
+	"\x05items\x18\x04 \x03(\v2 .validation.v1.ValidateBatchItemB\b\xbaH\x05\x92\x01\x02\b\x01R\x05itemsB\t\n" +
+	"\a_strict\"\xf9\x01\n" +
+	"\x11ValidateBatchItem\x12\x1f\n" +
+	"\vschema_name\x18\x01 \x01(\tR\n" +
+	"schemaName\x12#\n" +
+	"\fpayload_json\x18\x02 \x01(\tH\x00R\vpayloadJson\x12'\n" +
+	"\x0epayload_binary\x18\x03 \x01(\fH\x00R\rpayloadBinary\x12#\n" +
+	"\fpayload_text\x18\x04 \x01(\tH\x00R\vpayloadText\x12\x16\n" +
+	"\x06commit\x18\x05 \x01(\tR\x06commit\x12\x1b\n" +
+	"\x06strict\x18\x06 \x01(\bH\x01R\x06strict\x88\x01\x01B\x10\n" +
+	"\apayload\x12\x05\xbaH\x02\b\x01B\t\n" +
+	"\a_strict\"\xad\x01\n" +
+	"\x15ValidateBatchResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05valid\x18\x03 \x01(\x05R\x05valid\x12\x18\n" +
+	"\ainvalid\x18\x04 \x01(\x05R\ainvalid\x124\n" +
+	"\aresults\x18\x05 \x03(\v2\x1a.validation.v1.BatchResultR\aresults\"\xc6\x01\n" +
+	"\vBatchResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x126\n" +
+	"\x06errors\x18\x03 \x03(\v2\x1e.validation.v1.ValidationErrorR\x06errors\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"resolution\x18\x05 \x01(\v2\x19.validation.v1.ResolutionR\n" +
+	"resolution\"\xfe\x01\n" +
+	"\x10GetSchemaRequest\x12)\n" +
+	"\fmessage_name\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\vmessageName\x12\x16\n" +
+	"\x06commit\x18\x02 \x01(\tR\x06commit\x12D\n" +
+	"\vfield_names\x18\x03 \x01(\x0e2\x19.validation.v1.FieldNamesB\b\xbaH\x05\x82\x01\x02\x10\x01R\n" +
+	"fieldNames\x12\x16\n" +
+	"\x06strict\x18\x04 \x01(\bR\x06strict\x12\x1b\n" +
+	"\x06bundle\x18\x05 \x01(\bH\x00R\x06bundle\x88\x01\x01\x12!\n" +
+	"\fresolve_refs\x18\x06 \x01(\bR\vresolveRefsB\t\n" +
+	"\a_bundle\"o\n" +
+	"\x11GetSchemaResponse\x12\x1f\n" +
+	"\vjson_schema\x18\x01 \x01(\tR\n" +
+	"jsonSchema\x129\n" +
+	"\n" +
+	"resolution\x18\x02 \x01(\v2\x19.validation.v1.ResolutionR\n" +
+	"resolution\"\x15\n" +
+	"\x13ListMessagesRequest\"N\n" +
+	"\x14ListMessagesResponse\x126\n" +
+	"\bmessages\x18\x01 \x03(\v2\x1a.validation.v1.MessageInfoR\bmessages\"z\n" +
+	"\vMessageInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tfull_name\x18\x03 \x01(\tR\bfullName\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\"o\n" +
+	"\x12ListCommitsRequest\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"r\n" +
+	"\x13ListCommitsResponse\x123\n" +
+	"\acommits\x18\x01 \x03(\v2\x19.validation.v1.CommitInfoR\acommits\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xc0\x01\n" +
+	"\n" +
+	"CommitInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12;\n" +
+	"\vcreate_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12\x1f\n" +
+	"\vdigest_type\x18\x03 \x01(\tR\n" +
+	"digestType\x12!\n" +
+	"\fdigest_value\x18\x04 \x01(\tR\vdigestValue\x12!\n" +
+	"\fcheck_status\x18\x05 \x01(\tR\vcheckStatus*V\n" +
+	"\n" +
+	"FieldNames\x12\x1b\n" +
+	"\x17FIELD_NAMES_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11FIELD_NAMES_PROTO\x10\x01\x12\x14\n" +
+	"\x10FIELD_NAMES_JSON\x10\x022\xca\x03\n" +
+	"\x11ValidationService\x12Z\n" +
+	"\rValidateProto\x12#.validation.v1.ValidateProtoRequest\x1a$.validation.v1.ValidateProtoResponse\x12Z\n" +
+	"\rValidateBatch\x12#.validation.v1.ValidateBatchRequest\x1a$.validation.v1.ValidateBatchResponse\x12N\n" +
+	"\tGetSchema\x12\x1f.validation.v1.GetSchemaRequest\x1a .validation.v1.GetSchemaResponse\x12W\n" +
+	"\fListMessages\x12\".validation.v1.ListMessagesRequest\x1a#.validation.v1.ListMessagesResponse\x12T\n" +
+	"\vListCommits\x12!.validation.v1.ListCommitsRequest\x1a\".validation.v1.ListCommitsResponseB=Z;validation-service/backend/proto/validation/v1;validationv1b\x06proto3"
+
+var (
+	file_proto_validation_v1_validation_service_proto_rawDescOnce sync.Once
+	file_proto_validation_v1_validation_service_proto_rawDescData []byte
+)
+
+func file_proto_validation_v1_validation_service_proto_rawDescGZIP() []byte {
+	file_proto_validation_v1_validation_service_proto_rawDescOnce.Do(func() {
+		file_proto_validation_v1_validation_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_validation_v1_validation_service_proto_rawDesc), len(file_proto_validation_v1_validation_service_proto_rawDesc)))
+	})
+	return file_proto_validation_v1_validation_service_proto_rawDescData
+}
+
+var file_proto_validation_v1_validation_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_validation_v1_validation_service_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_proto_validation_v1_validation_service_proto_goTypes = []any{
+	(FieldNames)(0),               // 0: validation.v1.FieldNames
+	(*ValidateProtoRequest)(nil),  // 1: validation.v1.ValidateProtoRequest
+	(*ValidateProtoResponse)(nil), // 2: validation.v1.ValidateProtoResponse
+	(*Resolution)(nil),            // 3: validation.v1.Resolution
+	(*ValidationError)(nil),       // 4: validation.v1.ValidationError
+	(*ValidateBatchRequest)(nil),  // 5: validation.v1.ValidateBatchRequest
+	(*ValidateBatchItem)(nil),     // 6: validation.v1.ValidateBatchItem
+	(*ValidateBatchResponse)(nil), // 7: validation.v1.ValidateBatchResponse
+	(*BatchResult)(nil),           // 8: validation.v1.BatchResult
+	(*GetSchemaRequest)(nil),      // 9: validation.v1.GetSchemaRequest
+	(*GetSchemaResponse)(nil),     // 10: validation.v1.GetSchemaResponse
+	(*ListMessagesRequest)(nil),   // 11: validation.v1.ListMessagesRequest
+	(*ListMessagesResponse)(nil),  // 12: validation.v1.ListMessagesResponse
+	(*MessageInfo)(nil),           // 13: validation.v1.MessageInfo
+	(*ListCommitsRequest)(nil),    // 14: validation.v1.ListCommitsRequest
+	(*ListCommitsResponse)(nil),   // 15: validation.v1.ListCommitsResponse
+	(*CommitInfo)(nil),            // 16: validation.v1.CommitInfo
+	(*structpb.Value)(nil),        // 17: google.protobuf.Value
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
+}
+var file_proto_validation_v1_validation_service_proto_depIdxs = []int32{
+	4,  // 0: validation.v1.ValidateProtoResponse.errors:type_name -> validation.v1.ValidationError
+	3,  // 1: validation.v1.ValidateProtoResponse.resolution:type_name -> validation.v1.Resolution
+	17, // 2: validation.v1.ValidationError.value:type_name -> google.protobuf.Value
+	6,  // 3: validation.v1.ValidateBatchRequest.items:type_name -> validation.v1.ValidateBatchItem
+	8,  // 4: validation.v1.ValidateBatchResponse.results:type_name -> validation.v1.BatchResult
+	4,  // 5: validation.v1.BatchResult.errors:type_name -> validation.v1.ValidationError
+	3,  // 6: validation.v1.BatchResult.resolution:type_name -> validation.v1.Resolution
+	0,  // 7: validation.v1.GetSchemaRequest.field_names:type_name -> validation.v1.FieldNames
+	3,  // 8: validation.v1.GetSchemaResponse.resolution:type_name -> validation.v1.Resolution
+	13, // 9: validation.v1.ListMessagesResponse.messages:type_name -> validation.v1.MessageInfo
+	16, // 10: validation.v1.ListCommitsResponse.commits:type_name -> validation.v1.CommitInfo
+	18, // 11: validation.v1.CommitInfo.create_time:type_name -> google.protobuf.Timestamp
+	1,  // 12: validation.v1.ValidationService.ValidateProto:input_type -> validation.v1.ValidateProtoRequest
+	5,  // 13: validation.v1.ValidationService.ValidateBatch:input_type -> validation.v1.ValidateBatchRequest
+	9,  // 14: validation.v1.ValidationService.GetSchema:input_type -> validation.v1.GetSchemaRequest
+	11, // 15: validation.v1.ValidationService.ListMessages:input_type -> validation.v1.ListMessagesRequest
+	14, // 16: validation.v1.ValidationService.ListCommits:input_type -> validation.v1.ListCommitsRequest
+	2,  // 17: validation.v1.ValidationService.ValidateProto:output_type -> validation.v1.ValidateProtoResponse
+	7,  // 18: validation.v1.ValidationService.ValidateBatch:output_type -> validation.v1.ValidateBatchResponse
+	10, // 19: validation.v1.ValidationService.GetSchema:output_type -> validation.v1.GetSchemaResponse
+	12, // 20: validation.v1.ValidationService.ListMessages:output_type -> validation.v1.ListMessagesResponse
+	15, // 21: validation.v1.ValidationService.ListCommits:output_type -> validation.v1.ListCommitsResponse
+	17, // [17:22] is the sub-list for method output_type
+	12, // [12:17] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_proto_validation_v1_validation_service_proto_init() }
+func file_proto_validation_v1_validation_service_proto_init() {
+	if File_proto_validation_v1_validation_service_proto != nil {
+		return
+	}
+	file_proto_validation_v1_validation_service_proto_msgTypes[0].OneofWrappers = []any{
+		(*ValidateProtoRequest_PayloadJson)(nil),
+		(*ValidateProtoRequest_PayloadBinary)(nil),
+		(*ValidateProtoRequest_PayloadText)(nil),
+	}
+	file_proto_validation_v1_validation_service_proto_msgTypes[3].OneofWrappers = []any{}
+	file_proto_validation_v1_validation_service_proto_msgTypes[4].OneofWrappers = []any{}
+	file_proto_validation_v1_validation_service_proto_msgTypes[5].OneofWrappers = []any{
+		(*ValidateBatchItem_PayloadJson)(nil),
+		(*ValidateBatchItem_PayloadBinary)(nil),
+		(*ValidateBatchItem_PayloadText)(nil),
+	}
+	file_proto_validation_v1_validation_service_proto_msgTypes[8].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_validation_v1_validation_service_proto_rawDesc), len(file_proto_validation_v1_validation_service_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_validation_v1_validation_service_proto_goTypes,
+		DependencyIndexes: file_proto_validation_v1_validation_service_proto_depIdxs,
+		EnumInfos:         file_proto_validation_v1_validation_service_proto_enumTypes,
+		MessageInfos:      file_proto_validation_v1_validation_service_proto_msgTypes,
+	}.Build()
+	File_proto_validation_v1_validation_service_proto = out.File
+	file_proto_validation_v1_validation_service_proto_goTypes = nil
+	file_proto_validation_v1_validation_service_proto_depIdxs = nil
+}
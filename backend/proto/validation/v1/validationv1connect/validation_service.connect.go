@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: proto/validation/v1/validation_service.proto
+
+package validationv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	strings "strings"
+	v1 "validation-service/backend/proto/validation/v1"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// ValidationServiceName is the fully-qualified name of the ValidationService service.
+	ValidationServiceName = "validation.v1.ValidationService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// ValidationServiceValidateProtoProcedure is the fully-qualified name of the ValidationService's
+	// ValidateProto RPC.
+	ValidationServiceValidateProtoProcedure = "/validation.v1.ValidationService/ValidateProto"
+	// ValidationServiceValidateBatchProcedure is the fully-qualified name of the ValidationService's
+	// ValidateBatch RPC.
+	ValidationServiceValidateBatchProcedure = "/validation.v1.ValidationService/ValidateBatch"
+	// ValidationServiceGetSchemaProcedure is the fully-qualified name of the ValidationService's
+	// GetSchema RPC.
+	ValidationServiceGetSchemaProcedure = "/validation.v1.ValidationService/GetSchema"
+	// ValidationServiceListMessagesProcedure is the fully-qualified name of the ValidationService's
+	// ListMessages RPC.
+	ValidationServiceListMessagesProcedure = "/validation.v1.ValidationService/ListMessages"
+	// ValidationServiceListCommitsProcedure is the fully-qualified name of the ValidationService's
+	// ListCommits RPC.
+	ValidationServiceListCommitsProcedure = "/validation.v1.ValidationService/ListCommits"
+)
+
+// ValidationServiceClient is a client for the validation.v1.ValidationService service.
+type ValidationServiceClient interface {
+	// ValidateProto validates a single payload against a schema
+	ValidateProto(context.Context, *connect.Request[v1.ValidateProtoRequest]) (*connect.Response[v1.ValidateProtoResponse], error)
+	// ValidateBatch validates many payloads in one call
+	ValidateBatch(context.Context, *connect.Request[v1.ValidateBatchRequest]) (*connect.Response[v1.ValidateBatchResponse], error)
+	// GetSchema returns the JSON Schema generated for a message
+	GetSchema(context.Context, *connect.Request[v1.GetSchemaRequest]) (*connect.Response[v1.GetSchemaResponse], error)
+	// ListMessages lists the messages that can be validated
+	ListMessages(context.Context, *connect.Request[v1.ListMessagesRequest]) (*connect.Response[v1.ListMessagesResponse], error)
+	// ListCommits lists the BSR commits of a label, newest first
+	ListCommits(context.Context, *connect.Request[v1.ListCommitsRequest]) (*connect.Response[v1.ListCommitsResponse], error)
+}
+
+// NewValidationServiceClient constructs a client for the validation.v1.ValidationService service.
+// By default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped
+// responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewValidationServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) ValidationServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	validationServiceMethods := v1.File_proto_validation_v1_validation_service_proto.Services().ByName("ValidationService").Methods()
+	return &validationServiceClient{
+		validateProto: connect.NewClient[v1.ValidateProtoRequest, v1.ValidateProtoResponse](
+			httpClient,
+			baseURL+ValidationServiceValidateProtoProcedure,
+			connect.WithSchema(validationServiceMethods.ByName("ValidateProto")),
+			connect.WithClientOptions(opts...),
+		),
+		validateBatch: connect.NewClient[v1.ValidateBatchRequest, v1.ValidateBatchResponse](
+			httpClient,
+			baseURL+ValidationServiceValidateBatchProcedure,
+			connect.WithSchema(validationServiceMethods.ByName("ValidateBatch")),
+			connect.WithClientOptions(opts...),
+		),
+		getSchema: connect.NewClient[v1.GetSchemaRequest, v1.GetSchemaResponse](
+			httpClient,
+			baseURL+ValidationServiceGetSchemaProcedure,
+			connect.WithSchema(validationServiceMethods.ByName("GetSchema")),
+			connect.WithClientOptions(opts...),
+		),
+		listMessages: connect.NewClient[v1.ListMessagesRequest, v1.ListMessagesResponse](
+			httpClient,
+			baseURL+ValidationServiceListMessagesProcedure,
+			connect.WithSchema(validationServiceMethods.ByName("ListMessages")),
+			connect.WithClientOptions(opts...),
+		),
+		listCommits: connect.NewClient[v1.ListCommitsRequest, v1.ListCommitsResponse](
+			httpClient,
+			baseURL+ValidationServiceListCommitsProcedure,
+			connect.WithSchema(validationServiceMethods.ByName("ListCommits")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// validationServiceClient implements ValidationServiceClient.
+type validationServiceClient struct {
+	validateProto *connect.Client[v1.ValidateProtoRequest, v1.ValidateProtoResponse]
+	validateBatch *connect.Client[v1.ValidateBatchRequest, v1.ValidateBatchResponse]
+	getSchema     *connect.Client[v1.GetSchemaRequest, v1.GetSchemaResponse]
+	listMessages  *connect.Client[v1.ListMessagesRequest, v1.ListMessagesResponse]
+	listCommits   *connect.Client[v1.ListCommitsRequest, v1.ListCommitsResponse]
+}
+
+// ValidateProto calls validation.v1.ValidationService.ValidateProto.
+func (c *validationServiceClient) ValidateProto(ctx context.Context, req *connect.Request[v1.ValidateProtoRequest]) (*connect.Response[v1.ValidateProtoResponse], error) {
+	return c.validateProto.CallUnary(ctx, req)
+}
+
+// ValidateBatch calls validation.v1.ValidationService.ValidateBatch.
+func (c *validationServiceClient) ValidateBatch(ctx context.Context, req *connect.Request[v1.ValidateBatchRequest]) (*connect.Response[v1.ValidateBatchResponse], error) {
+	return c.validateBatch.CallUnary(ctx, req)
+}
+
+// GetSchema calls validation.v1.ValidationService.GetSchema.
+func (c *validationServiceClient) GetSchema(ctx context.Context, req *connect.Request[v1.GetSchemaRequest]) (*connect.Response[v1.GetSchemaResponse], error) {
+	return c.getSchema.CallUnary(ctx, req)
+}
+
+// ListMessages calls validation.v1.ValidationService.ListMessages.
+func (c *validationServiceClient) ListMessages(ctx context.Context, req *connect.Request[v1.ListMessagesRequest]) (*connect.Response[v1.ListMessagesResponse], error) {
+	return c.listMessages.CallUnary(ctx, req)
+}
+
+// ListCommits calls validation.v1.ValidationService.ListCommits.
+func (c *validationServiceClient) ListCommits(ctx context.Context, req *connect.Request[v1.ListCommitsRequest]) (*connect.Response[v1.ListCommitsResponse], error) {
+	return c.listCommits.CallUnary(ctx, req)
+}
+
+// ValidationServiceHandler is an implementation of the validation.v1.ValidationService service.
+type ValidationServiceHandler interface {
+	// ValidateProto validates a single payload against a schema
+	ValidateProto(context.Context, *connect.Request[v1.ValidateProtoRequest]) (*connect.Response[v1.ValidateProtoResponse], error)
+	// ValidateBatch validates many payloads in one call
+	ValidateBatch(context.Context, *connect.Request[v1.ValidateBatchRequest]) (*connect.Response[v1.ValidateBatchResponse], error)
+	// GetSchema returns the JSON Schema generated for a message
+	GetSchema(context.Context, *connect.Request[v1.GetSchemaRequest]) (*connect.Response[v1.GetSchemaResponse], error)
+	// ListMessages lists the messages that can be validated
+	ListMessages(context.Context, *connect.Request[v1.ListMessagesRequest]) (*connect.Response[v1.ListMessagesResponse], error)
+	// ListCommits lists the BSR commits of a label, newest first
+	ListCommits(context.Context, *connect.Request[v1.ListCommitsRequest]) (*connect.Response[v1.ListCommitsResponse], error)
+}
+
+// NewValidationServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewValidationServiceHandler(svc ValidationServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	validationServiceMethods := v1.File_proto_validation_v1_validation_service_proto.Services().ByName("ValidationService").Methods()
+	validationServiceValidateProtoHandler := connect.NewUnaryHandler(
+		ValidationServiceValidateProtoProcedure,
+		svc.ValidateProto,
+		connect.WithSchema(validationServiceMethods.ByName("ValidateProto")),
+		connect.WithHandlerOptions(opts...),
+	)
+	validationServiceValidateBatchHandler := connect.NewUnaryHandler(
+		ValidationServiceValidateBatchProcedure,
+		svc.ValidateBatch,
+		connect.WithSchema(validationServiceMethods.ByName("ValidateBatch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	validationServiceGetSchemaHandler := connect.NewUnaryHandler(
+		ValidationServiceGetSchemaProcedure,
+		svc.GetSchema,
+		connect.WithSchema(validationServiceMethods.ByName("GetSchema")),
+		connect.WithHandlerOptions(opts...),
+	)
+	validationServiceListMessagesHandler := connect.NewUnaryHandler(
+		ValidationServiceListMessagesProcedure,
+		svc.ListMessages,
+		connect.WithSchema(validationServiceMethods.ByName("ListMessages")),
+		connect.WithHandlerOptions(opts...),
+	)
+	validationServiceListCommitsHandler := connect.NewUnaryHandler(
+		ValidationServiceListCommitsProcedure,
+		svc.ListCommits,
+		connect.WithSchema(validationServiceMethods.ByName("ListCommits")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/validation.v1.ValidationService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ValidationServiceValidateProtoProcedure:
+			validationServiceValidateProtoHandler.ServeHTTP(w, r)
+		case ValidationServiceValidateBatchProcedure:
+			validationServiceValidateBatchHandler.ServeHTTP(w, r)
+		case ValidationServiceGetSchemaProcedure:
+			validationServiceGetSchemaHandler.ServeHTTP(w, r)
+		case ValidationServiceListMessagesProcedure:
+			validationServiceListMessagesHandler.ServeHTTP(w, r)
+		case ValidationServiceListCommitsProcedure:
+			validationServiceListCommitsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedValidationServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedValidationServiceHandler struct{}
+
+func (UnimplementedValidationServiceHandler) ValidateProto(context.Context, *connect.Request[v1.ValidateProtoRequest]) (*connect.Response[v1.ValidateProtoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("validation.v1.ValidationService.ValidateProto is not implemented"))
+}
+
+func (UnimplementedValidationServiceHandler) ValidateBatch(context.Context, *connect.Request[v1.ValidateBatchRequest]) (*connect.Response[v1.ValidateBatchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("validation.v1.ValidationService.ValidateBatch is not implemented"))
+}
+
+func (UnimplementedValidationServiceHandler) GetSchema(context.Context, *connect.Request[v1.GetSchemaRequest]) (*connect.Response[v1.GetSchemaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("validation.v1.ValidationService.GetSchema is not implemented"))
+}
+
+func (UnimplementedValidationServiceHandler) ListMessages(context.Context, *connect.Request[v1.ListMessagesRequest]) (*connect.Response[v1.ListMessagesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("validation.v1.ValidationService.ListMessages is not implemented"))
+}
+
+func (UnimplementedValidationServiceHandler) ListCommits(context.Context, *connect.Request[v1.ListCommitsRequest]) (*connect.Response[v1.ListCommitsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("validation.v1.ValidationService.ListCommits is not implemented"))
+}
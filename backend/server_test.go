@@ -6,13 +6,25 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"validation-service/backend/connectserver"
+	"validation-service/backend/grpcserver"
 	"validation-service/backend/handler"
 	"validation-service/backend/logger"
+	"validation-service/backend/proto"
+	"validation-service/backend/proto/protoconnect"
+	taskv1 "validation-service/backend/proto/task/v1"
+	"validation-service/backend/proto/task/v1/taskv1connect"
+	validationv1 "validation-service/backend/proto/validation/v1"
+	"validation-service/backend/proto/validation/v1/validationv1connect"
 	"validation-service/backend/service"
 
 	"buf.build/go/protovalidate"
@@ -29,16 +41,10 @@ func startTestServer(t *testing.T) string {
 		t.Fatalf("Failed to create validator: %v", err)
 	}
 
-	// Get base path
-	basePath, err := filepath.Abs(".")
-	if err != nil {
-		t.Fatalf("Failed to get base path: %v", err)
-	}
-
 	// Initialize services
-	_ = service.NewSchemaService("sanjeev-personal", "validation", basePath) // Not used in tests but needed for initialization
-	validationService := service.NewValidationService(validator)
-	validationHandler := handler.NewValidationHandler(validationService)
+	validationService := service.NewValidationService(validator, service.NewRegistryDescriptorSource(nil), false)
+	validationHandler := handler.NewValidationHandler(validationService, 4, 100, 1<<20)
+	taskHandler := handler.NewTaskHandler(service.NewTaskService(service.NewMemoryTaskStore()), validator)
 
 	// Find an available port
 	listener, err := net.Listen("tcp", ":0")
@@ -53,6 +59,10 @@ func startTestServer(t *testing.T) string {
 	// Create HTTP server
 	mux := http.NewServeMux()
 	mux.HandleFunc("/api/v1/validate-proto", validationHandler.ValidateProto)
+	mux.HandleFunc("/api/v1/validate-proto/batch", validationHandler.ValidateBatch)
+	mux.HandleFunc("/api/v1/validate-proto/stream", validationHandler.ValidateStream)
+	mux.HandleFunc("/api/v1/tasks", taskHandler.Tasks)
+	mux.HandleFunc("/api/v1/tasks/", taskHandler.Task)
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", port),
@@ -89,19 +99,116 @@ func startTestServer(t *testing.T) string {
 	return baseURL
 }
 
+// testServices are the gRPC service implementations shared by the gRPC and Connect test servers
+type testServices struct {
+	validator        protovalidate.Validator
+	validationServer *grpcserver.ValidationServer
+	taskServer       *grpcserver.TaskServer
+}
+
+// newTestServices creates the services backing the gRPC and Connect test servers, using the
+// compiled-in descriptors and the generated JSON Schema files
+func newTestServices(t *testing.T) *testServices {
+	// Initialize logger
+	logger.Init()
+
+	// Create validator instance
+	validator, err := protovalidate.New()
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	// Get base path
+	basePath, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatalf("Failed to get base path: %v", err)
+	}
+
+	// Initialize services
+	schemaService := service.NewSchemaService(service.NewLocalSchemaSource(filepath.Join(basePath, "gen", "jsonschema")))
+	validationService := service.NewValidationService(validator, service.NewRegistryDescriptorSource(nil), false)
+	commitsService := service.NewCommitsService("sanjeev-personal", "validation", "")
+
+	return &testServices{
+		validator:        validator,
+		validationServer: grpcserver.NewValidationServer(validationService, schemaService, commitsService, 4, 100),
+		taskServer:       grpcserver.NewTaskServer(service.NewTaskService(service.NewMemoryTaskStore())),
+	}
+}
+
+// startTestGRPCServer starts a gRPC server hosting the GreetingService and ValidationService on an available port
+// and returns a client connection to it
+func startTestGRPCServer(t *testing.T) *grpc.ClientConn {
+	services := newTestServices(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcserver.UnaryServerInterceptor(services.validator, grpcserver.WithResponseValidation())),
+		grpc.ChainStreamInterceptor(grpcserver.StreamServerInterceptor(services.validator, grpcserver.WithResponseValidation())),
+	)
+	proto.RegisterGreetingServiceServer(server, &greetingServer{})
+	validationv1.RegisterValidationServiceServer(server, services.validationServer)
+	taskv1.RegisterTaskServiceServer(server, services.taskServer)
+
+	// Start server in a goroutine
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			t.Logf("gRPC server error: %v", err)
+		}
+	}()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create gRPC client: %v", err)
+	}
+
+	// Cleanup function
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	return conn
+}
+
 // validateProtoRequest represents the request payload for validation API
 type validateProtoRequest struct {
 	SchemaName string          `json:"schemaName"`
 	Payload    json.RawMessage `json:"payload"`
 }
 
+// startTestConnectServer serves the gRPC services over Connect, gRPC-Web and gRPC
+// (cleartext HTTP/2), behind corsMiddleware as on the HTTP port, and returns the base URL
+func startTestConnectServer(t *testing.T) string {
+	services := newTestServices(t)
+	bridge := connectserver.NewBridge(grpcserver.UnaryServerInterceptor(services.validator, grpcserver.WithResponseValidation()))
+
+	mux := http.NewServeMux()
+	path, h := protoconnect.NewGreetingServiceHandler(connectserver.NewGreetingService(bridge, &greetingServer{}))
+	mux.HandleFunc(path, corsMiddleware(h.ServeHTTP))
+	path, h = validationv1connect.NewValidationServiceHandler(connectserver.NewValidationService(bridge, services.validationServer))
+	mux.HandleFunc(path, corsMiddleware(h.ServeHTTP))
+	path, h = taskv1connect.NewTaskServiceHandler(connectserver.NewTaskService(bridge, services.taskServer))
+	mux.HandleFunc(path, corsMiddleware(h.ServeHTTP))
+
+	server := httptest.NewUnstartedServer(mux)
+	server.Config.Protocols = new(http.Protocols)
+	server.Config.Protocols.SetHTTP1(true)
+	server.Config.Protocols.SetUnencryptedHTTP2(true)
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
 // validateProtoResponse represents the response from validation API
 type validateProtoResponse struct {
-	Success bool `json:"success"`
-	Errors  []struct {
-		Friendly  string `json:"friendly"`
-		Technical string `json:"technical"`
-	} `json:"errors"`
+	Success bool              `json:"success"`
+	Errors  []structuredError `json:"errors"`
 }
 
 // callValidateAPI makes a POST request to the validate-proto endpoint
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"sync"
+	"validation-service/backend/logger"
+)
+
+// BatchItem is a single payload to validate as part of a batch
+type BatchItem struct {
+	SchemaName string
+	Payload    []byte
+	Commit     string
+	Options    ValidateOptions
+}
+
+// BatchResult is the validation outcome for a single batch item
+// The embedded Resolution is empty if the item's schema could not be resolved
+type BatchResult struct {
+	Index   int               `json:"index"`
+	Success bool              `json:"success"`
+	Errors  []ValidationError `json:"errors"`
+	Error   string            `json:"error,omitempty"` // Processing error (unknown schema, invalid JSON, etc.)
+	Resolution
+}
+
+// ValidateBatch validates many payloads concurrently using a bounded pool of workers
+// Results are returned in the same order as items, each carrying its item index
+// A processing error for one item does not stop the rest of the batch
+func (s *ValidationService) ValidateBatch(items []BatchItem, workers int) []BatchResult {
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+	logger.Debug("ValidateBatch called with %d item(s) and %d worker(s)", len(items), workers)
+
+	results := make([]BatchResult, len(items))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = s.validateBatchItem(i, items[i])
+			}
+		}()
+	}
+
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// validateBatchItem validates a single batch item and converts the outcome into a BatchResult
+func (s *ValidationService) validateBatchItem(index int, item BatchItem) BatchResult {
+	success, errors, resolution, err := s.ValidatePayload(item.SchemaName, item.Payload, item.Commit, item.Options)
+	if err != nil {
+		logger.Debug("Batch item %d failed to validate for schemaName=%s: %v", index, item.SchemaName, err)
+		return BatchResult{
+			Index:      index,
+			Errors:     []ValidationError{},
+			Error:      err.Error(),
+			Resolution: resolution,
+		}
+	}
+
+	return BatchResult{
+		Index:      index,
+		Success:    success,
+		Errors:     errors,
+		Resolution: resolution,
+	}
+}
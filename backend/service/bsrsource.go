@@ -0,0 +1,423 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"sync"
+	"time"
+	"validation-service/backend/config"
+	"validation-service/backend/logger"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// GetFileDescriptorSetRequest represents the request body for BSR Reflection API
+type GetFileDescriptorSetRequest struct {
+	Module  string   `json:"module"`
+	Version string   `json:"version,omitempty"`
+	Symbols []string `json:"symbols,omitempty"`
+}
+
+// GetFileDescriptorSetResponse represents the response from BSR Reflection API
+// The fileDescriptorSet field is a JSON object that needs to be unmarshaled separately
+type GetFileDescriptorSetResponse struct {
+	FileDescriptorSet json.RawMessage `json:"fileDescriptorSet"`
+	Version           string          `json:"version,omitempty"`
+}
+
+// BSRDescriptorSource resolves messages with the BSR Reflection API
+// Concurrent fetches for the same module, version and symbol share a single round-trip
+type BSRDescriptorSource struct {
+	bsrOrg            string
+	bsrModule         string
+	bsrToken          string
+	httpClient        *http.Client
+	commits           *CommitsService // Looks up module digests, shares httpClient
+	descriptorFlights flightGroup[*ResolvedMessage]
+}
+
+// NewBSRDescriptorSource creates a source for the buf.build/{bsrOrg}/{bsrModule} module
+func NewBSRDescriptorSource(bsrOrg, bsrModule, bsrToken string) *BSRDescriptorSource {
+	logger.Debug("Initializing BSRDescriptorSource with org=%s, module=%s", bsrOrg, bsrModule)
+	httpClient := &http.Client{}
+	return &BSRDescriptorSource{
+		bsrOrg:     bsrOrg,
+		bsrModule:  bsrModule,
+		bsrToken:   bsrToken,
+		httpClient: httpClient,
+		commits:    newCommitsService(bsrOrg, bsrModule, bsrToken, httpClient),
+	}
+}
+
+// Name implements DescriptorSource
+func (s *BSRDescriptorSource) Name() string {
+	return "bsr"
+}
+
+// ModuleName returns the full BSR module name, e.g. buf.build/{org}/{module}
+func (s *BSRDescriptorSource) ModuleName() string {
+	return fmt.Sprintf("buf.build/%s/%s", s.bsrOrg, s.bsrModule)
+}
+
+// FindMessage implements DescriptorSource
+// version is the commit ID or label to fetch, it defaults to defaultBSRLabel if empty
+func (s *BSRDescriptorSource) FindMessage(schemaName, version string) (*ResolvedMessage, error) {
+	moduleName := s.ModuleName()
+
+	if version == "" {
+		version = defaultBSRLabel()
+	}
+
+	// Coalesce concurrent fetches for the same module, version and symbol
+	key := moduleName + "|" + version + "|" + schemaName
+	resolved, shared, err := s.descriptorFlights.Do(key, func() (*ResolvedMessage, error) {
+		return s.fetchMessage(moduleName, version, schemaName)
+	})
+	if shared {
+		logger.Debug("Shared BSR descriptor fetch result for %s (module=%s, version=%s)", schemaName, moduleName, version)
+	}
+	return resolved, err
+}
+
+// defaultBSRLabel returns the label the BSR sources fetch when no version is given,
+// BSR_VERSION or "main"
+func defaultBSRLabel() string {
+	return config.GetEnv("BSR_VERSION", "main")
+}
+
+// fetchMessage performs the BSR Reflection API round-trip and finds schemaName in the result
+func (s *BSRDescriptorSource) fetchMessage(moduleName, version, schemaName string) (*ResolvedMessage, error) {
+	files, resolvedVersion, err := s.fetchFileDescriptorSet(moduleName, version, schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the fetched registry is searched, a compiled-in type of the same name must not
+	// shadow the requested commit
+	md, err := findMessageInFiles(files, schemaName)
+	if err != nil {
+		logger.Debug("Failed to find descriptor in BSR files for schemaName=%s: %v", schemaName, err)
+		return nil, err
+	}
+	return &ResolvedMessage{
+		Descriptor:   md,
+		Files:        files,
+		Source:       s.Name(),
+		Commit:       resolvedVersion,
+		Digest:       descriptorDigest(md),
+		ModuleDigest: lookupModuleDigest(s.commits, resolvedVersion),
+	}, nil
+}
+
+// lookupModuleDigest returns the BSR module digest of commitID, or "" if it cannot be
+// looked up; a missing digest never fails the resolution
+func lookupModuleDigest(commits *CommitsService, commitID string) string {
+	if !isCommitID(commitID) {
+		return ""
+	}
+	digest, err := commits.ModuleDigest(commitID)
+	if err != nil {
+		logger.Warn("Failed to look up module digest of commit %s: %v", commitID, err)
+		return ""
+	}
+	return digest
+}
+
+// fetchFileDescriptorSet fetches the FileDescriptorSet for schemaName from the BSR Reflection API
+// Returns the files and the concrete version reported by BSR
+func (s *BSRDescriptorSource) fetchFileDescriptorSet(moduleName, version, schemaName string) (*protoregistry.Files, string, error) {
+	fds, resolvedVersion, err := s.fetchDescriptorSet(moduleName, version, []string{schemaName})
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Convert FileDescriptorSet to *protoregistry.Files
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		logger.Error("Failed to create Files from FileDescriptorSet: %v", err)
+		return nil, "", fmt.Errorf("failed to create Files: %w", err)
+	}
+
+	logger.Debug("Successfully created Files from BSR descriptor (version: %s)", resolvedVersion)
+	return files, resolvedVersion, nil
+}
+
+// FetchImage fetches the FileDescriptorSet of the whole module at version, a commit ID or
+// label, from the BSR Reflection API
+// Returns the set and the commit version resolved to
+func (s *BSRDescriptorSource) FetchImage(version string) (*descriptorpb.FileDescriptorSet, string, error) {
+	return s.fetchDescriptorSet(s.ModuleName(), version, nil)
+}
+
+// fetchDescriptorSet performs the BSR Reflection API round-trip for symbols, all of the
+// module if empty
+// Returns the set and the concrete version reported by BSR
+func (s *BSRDescriptorSource) fetchDescriptorSet(moduleName, version string, symbols []string) (*descriptorpb.FileDescriptorSet, string, error) {
+
+	// Build request body with symbols (fully qualified message names)
+	requestBody := GetFileDescriptorSetRequest{
+		Module:  moduleName,
+		Version: version,
+		Symbols: symbols,
+	}
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		logger.Error("Failed to marshal request body: %v", err)
+		return nil, "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Build BSR Reflection API URL
+	url := "https://buf.build/buf.reflect.v1beta1.FileDescriptorSetService/GetFileDescriptorSet"
+
+	// Log URL and request body in debug mode
+	logger.Debug("BSR Reflection API URL: %s", url)
+	logger.Debug("BSR Reflection API Request Body: %s", string(jsonBody))
+	logger.Debug("Fetching descriptor from BSR Reflection API: module=%s, version=%s, symbols=%v", moduleName, version, requestBody.Symbols)
+
+	// Create HTTP POST request
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		logger.Error("Failed to create HTTP request for URL %s: %v", url, err)
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Content-Type", "application/json")
+	if s.bsrToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.bsrToken))
+		logger.Debug("Added Bearer token to BSR request")
+	}
+
+	// Execute the request
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		logger.Error("HTTP POST request failed for URL %s: %v", url, err)
+		return nil, "", fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	logger.Debug("BSR HTTP response status: %d %s", resp.StatusCode, resp.Status)
+
+	if resp.StatusCode == http.StatusNotFound {
+		logger.Debug("Descriptor not found in BSR (404)")
+		return nil, "", fmt.Errorf("descriptor %w in BSR", ErrNotFound)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// Try to read error body for better error messages
+		errorBody, _ := io.ReadAll(resp.Body)
+		logger.Error("BSR returned unexpected status code %d: %s", resp.StatusCode, string(errorBody))
+		return nil, "", fmt.Errorf("BSR returned status code %d", resp.StatusCode)
+	}
+
+	// Read JSON response
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logger.Error("Failed to read BSR response body: %v", err)
+		return nil, "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	logger.Debug("Successfully read BSR response body (size: %d bytes)", len(data))
+
+	// Parse JSON response
+	var apiResponse GetFileDescriptorSetResponse
+	if err := json.Unmarshal(data, &apiResponse); err != nil {
+		logger.Error("Failed to unmarshal JSON response: %v", err)
+		return nil, "", fmt.Errorf("failed to unmarshal JSON response: %w", err)
+	}
+
+	if len(apiResponse.FileDescriptorSet) == 0 {
+		logger.Error("FileDescriptorSet is empty in API response")
+		return nil, "", fmt.Errorf("FileDescriptorSet is empty in API response")
+	}
+
+	// Unmarshal FileDescriptorSet from JSON using protojson
+	var fds descriptorpb.FileDescriptorSet
+	unmarshalOpts := protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	}
+	if err := unmarshalOpts.Unmarshal(apiResponse.FileDescriptorSet, &fds); err != nil {
+		logger.Error("Failed to unmarshal FileDescriptorSet from JSON: %v", err)
+		return nil, "", fmt.Errorf("failed to unmarshal FileDescriptorSet: %w", err)
+	}
+	return &fds, apiResponse.Version, nil
+}
+
+// BSRSchemaSource fetches JSON Schema documents from the protoschema-jsonschema
+// generated archive of a BSR module
+// Concurrent requests for the same schema share a single upstream fetch
+type BSRSchemaSource struct {
+	bsrOrg        string
+	bsrModule     string
+	bsrToken      string
+	httpClient    *http.Client
+	commits       *CommitsService // Resolves labels and looks up module digests, shares httpClient
+	schemaFlights flightGroup[[]byte]
+
+	mu           sync.Mutex
+	labelTTL     time.Duration
+	labelCommits map[string]labelCommit // Label to the commit it resolved to, until expiresAt
+	now          func() time.Time       // Clock for label expiry, replaced in tests
+}
+
+// labelCommit is a memoized resolution of a label to a commit
+type labelCommit struct {
+	commit    string
+	expiresAt time.Time
+}
+
+// NewBSRSchemaSource creates a source for the buf.build/{bsrOrg}/{bsrModule} module
+// labelTTL controls how long the commit a label resolves to is reused (0 resolves labels
+// on every request), normally the label TTL of the descriptor cache
+func NewBSRSchemaSource(bsrOrg, bsrModule, bsrToken string, labelTTL time.Duration) *BSRSchemaSource {
+	logger.Debug("Initializing BSRSchemaSource with org=%s, module=%s, labelTTL=%s", bsrOrg, bsrModule, labelTTL)
+	httpClient := &http.Client{}
+	return &BSRSchemaSource{
+		bsrOrg:       bsrOrg,
+		bsrModule:    bsrModule,
+		bsrToken:     bsrToken,
+		httpClient:   httpClient,
+		commits:      newCommitsService(bsrOrg, bsrModule, bsrToken, httpClient),
+		labelTTL:     labelTTL,
+		labelCommits: make(map[string]labelCommit),
+		now:          time.Now,
+	}
+}
+
+// Name implements SchemaSource
+func (s *BSRSchemaSource) Name() string {
+	return "bsr"
+}
+
+// GetSchema implements SchemaSource
+// A label ("" for defaultBSRLabel) is first resolved to the commit it points to, memoized
+// for the label TTL, and the generated
+// archive of that commit is fetched, so the result names the commit it was generated from
+// If the label cannot be resolved, the archive of the label is fetched and Commit is left
+// empty, meaning unresolved
+func (s *BSRSchemaSource) GetSchema(messageName, version string, variant SchemaVariant) (*ResolvedSchema, error) {
+	if version == "" {
+		version = defaultBSRLabel()
+	}
+	commit := s.resolveCommit(version)
+	archiveVersion := version
+	if commit != "" {
+		archiveVersion = commit
+	}
+
+	url := s.buildBSRURL(variant.FileName(messageName), archiveVersion)
+	data, shared, err := s.schemaFlights.Do(url, func() ([]byte, error) {
+		return s.fetchURLFromBSR(url, messageName)
+	})
+	if shared {
+		logger.Debug("Shared BSR schema fetch result for messageName=%s", messageName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ResolvedSchema{Data: data, Source: s.Name(), Commit: commit, ModuleDigest: lookupModuleDigest(s.commits, commit)}, nil
+}
+
+// resolveCommit returns the commit ID version refers to, or "" if a label cannot be resolved
+func (s *BSRSchemaSource) resolveCommit(version string) string {
+	if isCommitID(version) {
+		return version
+	}
+	label := version
+
+	s.mu.Lock()
+	memoized, ok := s.labelCommits[label]
+	s.mu.Unlock()
+	if ok && s.now().Before(memoized.expiresAt) {
+		logger.Debug("Using memoized commit %s of label %s", memoized.commit, label)
+		return memoized.commit
+	}
+
+	commit, err := s.commits.ResolveLabel(label)
+	if err != nil {
+		logger.Warn("Failed to resolve label %s to a commit, fetching the label's archive unresolved: %v", label, err)
+		return ""
+	}
+	logger.Debug("Resolved label %s to commit %s", label, commit)
+	if s.labelTTL > 0 {
+		s.mu.Lock()
+		s.labelCommits[label] = labelCommit{commit: commit, expiresAt: s.now().Add(s.labelTTL)}
+		s.mu.Unlock()
+	}
+	return commit
+}
+
+// fetchURLFromBSR performs the HTTP GET for a schema artifact URL
+func (s *BSRSchemaSource) fetchURLFromBSR(url, messageName string) ([]byte, error) {
+	logger.Debug("Fetching from BSR URL: %s", url)
+
+	// Create HTTP request
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		logger.Error("Failed to create HTTP request for URL %s: %v", url, err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Add Bearer token authentication if available
+	if s.bsrToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.bsrToken))
+		logger.Debug("Added Bearer token to BSR request")
+	}
+
+	// Execute the request
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		logger.Error("HTTP GET request failed for URL %s: %v", url, err)
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	logger.Debug("BSR HTTP response status: %d %s", resp.StatusCode, resp.Status)
+
+	if resp.StatusCode == http.StatusNotFound {
+		logger.Debug("Schema not found in BSR (404) for messageName=%s", messageName)
+		return nil, fmt.Errorf("schema %w in BSR", ErrNotFound)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		logger.Error("BSR returned unexpected status code %d for messageName=%s", resp.StatusCode, messageName)
+		return nil, fmt.Errorf("BSR returned status code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logger.Error("Failed to read BSR response body: %v", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	logger.Debug("Successfully read BSR response body (size: %d bytes)", len(data))
+	return data, nil
+}
+
+// buildBSRURL constructs the BSR URL for fetching a schema file generated for version,
+// fileName is the name of the variant of the schema, see SchemaVariant.FileName
+func (s *BSRSchemaSource) buildBSRURL(fileName, version string) string {
+	// The archive of the default label is published as "latest"
+	if version == "" || version == "main" {
+		version = "latest"
+	}
+
+	// URL format: https://buf.build/gen/archive/{org}/{module}/bufbuild/protoschema-jsonschema/raw/{VERSION}/{FULL_NAME}.{VARIANT}.json
+	url := fmt.Sprintf(
+		"https://buf.build/gen/archive/%s/%s/bufbuild/protoschema-jsonschema/raw/%s/%s",
+		s.bsrOrg,
+		s.bsrModule,
+		neturl.PathEscape(version),
+		fileName,
+	)
+	logger.Debug("Built BSR URL for %s: %s", fileName, url)
+	return url
+}
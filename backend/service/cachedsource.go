@@ -0,0 +1,54 @@
+package service
+
+import (
+	"validation-service/backend/logger"
+)
+
+// cachedSourceName is reported as the source of lookups served from the cache
+const cachedSourceName = "cache"
+
+// CachedDescriptorSource serves repeated lookups of another source from a DescriptorCache
+// Lookups by label expire with the cache's label TTL, lookups by commit ID never expire
+type CachedDescriptorSource struct {
+	source DescriptorSource
+	cache  *DescriptorCache
+	module string
+}
+
+// NewCachedDescriptorSource wraps source with cache
+// module namespaces the cache entries, e.g. the BSR module name, so one cache can be
+// shared by several sources
+func NewCachedDescriptorSource(source DescriptorSource, cache *DescriptorCache, module string) *CachedDescriptorSource {
+	return &CachedDescriptorSource{
+		source: source,
+		cache:  cache,
+		module: module,
+	}
+}
+
+// Name implements DescriptorSource
+func (s *CachedDescriptorSource) Name() string {
+	return s.source.Name()
+}
+
+// FindMessage implements DescriptorSource
+func (s *CachedDescriptorSource) FindMessage(schemaName, version string) (*ResolvedMessage, error) {
+	if cached, ok := s.cache.Get(s.module, version, schemaName); ok {
+		logger.Debug("Using cached descriptor for %s (module=%s, version=%s)", schemaName, s.module, version)
+		hit := *cached
+		hit.Source = cachedSourceName
+		return &hit, nil
+	}
+
+	resolved, err := s.source.FindMessage(schemaName, version)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Add(s.module, version, schemaName, resolved)
+	return resolved, nil
+}
+
+// CacheStats returns a snapshot of the cache counters
+func (s *CachedDescriptorSource) CacheStats() DescriptorCacheStats {
+	return s.cache.Stats()
+}
@@ -2,38 +2,49 @@ package service
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"validation-service/backend/logger"
 )
 
 // CommitsService handles fetching commit history from Buf registry
 type CommitsService struct {
-	bsrOrg     string
-	bsrModule  string
-	bsrToken   string
-	httpClient *http.Client
+	bsrOrg        string
+	bsrModule     string
+	bsrToken      string
+	httpClient    *http.Client
+	moduleDigests sync.Map // Commit ID to module digest, commits are immutable
+	labelFlights  flightGroup[string]
 }
 
 // NewCommitsService creates a new commits service instance
 func NewCommitsService(bsrOrg, bsrModule, bsrToken string) *CommitsService {
 	logger.Debug("Initializing CommitsService with org=%s, module=%s", bsrOrg, bsrModule)
+	return newCommitsService(bsrOrg, bsrModule, bsrToken, &http.Client{})
+}
+
+// newCommitsService creates a commits service sending its requests with httpClient
+func newCommitsService(bsrOrg, bsrModule, bsrToken string, httpClient *http.Client) *CommitsService {
 	return &CommitsService{
 		bsrOrg:     bsrOrg,
 		bsrModule:  bsrModule,
 		bsrToken:   bsrToken,
-		httpClient: &http.Client{},
+		httpClient: httpClient,
 	}
 }
 
 // ListLabelHistoryRequest represents the request body for Buf LabelService ListLabelHistory API
 type ListLabelHistoryRequest struct {
-	PageSize  int32                   `json:"pageSize,omitempty"`
-	LabelRef  *LabelRef               `json:"labelRef,omitempty"`
-	Order     string                  `json:"order,omitempty"`
-	PageToken string                  `json:"pageToken,omitempty"`
+	PageSize  int32     `json:"pageSize,omitempty"`
+	LabelRef  *LabelRef `json:"labelRef,omitempty"`
+	Order     string    `json:"order,omitempty"`
+	PageToken string    `json:"pageToken,omitempty"`
 }
 
 // LabelRef represents the label reference in the request
@@ -50,24 +61,24 @@ type LabelName struct {
 
 // ListLabelHistoryResponse represents the response from Buf LabelService ListLabelHistory API
 type ListLabelHistoryResponse struct {
-	NextPageToken string                 `json:"nextPageToken,omitempty"`
-	Values        []LabelHistoryValue    `json:"values,omitempty"`
+	NextPageToken string              `json:"nextPageToken,omitempty"`
+	Values        []LabelHistoryValue `json:"values,omitempty"`
 }
 
 // LabelHistoryValue represents a single commit in the label history
 type LabelHistoryValue struct {
-	Commit          *Commit          `json:"commit,omitempty"`
+	Commit           *Commit           `json:"commit,omitempty"`
 	CommitCheckState *CommitCheckState `json:"commitCheckState,omitempty"`
 }
 
 // Commit represents commit information
 type Commit struct {
-	ID             string  `json:"id,omitempty"`
-	CreateTime     string  `json:"createTime,omitempty"`
-	OwnerID        string  `json:"ownerId,omitempty"`
-	ModuleID       string  `json:"moduleId,omitempty"`
-	Digest         *Digest `json:"digest,omitempty"`
-	CreatedByUserID string `json:"createdByUserId,omitempty"`
+	ID              string  `json:"id,omitempty"`
+	CreateTime      string  `json:"createTime,omitempty"`
+	OwnerID         string  `json:"ownerId,omitempty"`
+	ModuleID        string  `json:"moduleId,omitempty"`
+	Digest          *Digest `json:"digest,omitempty"`
+	CreatedByUserID string  `json:"createdByUserId,omitempty"`
 }
 
 // Digest represents the commit digest
@@ -78,7 +89,7 @@ type Digest struct {
 
 // CommitCheckState represents the commit check state
 type CommitCheckState struct {
-	Status    string `json:"status,omitempty"`
+	Status     string `json:"status,omitempty"`
 	UpdateTime string `json:"updateTime,omitempty"`
 }
 
@@ -176,3 +187,115 @@ func (s *CommitsService) ListCommits(pageSize int, label string, pageToken strin
 	logger.Info("Successfully fetched %d commit(s) from Buf for label=%s", len(apiResponse.Values), label)
 	return &apiResponse, nil
 }
+
+// GetCommitsRequest represents the request body for Buf CommitService GetCommits API
+type GetCommitsRequest struct {
+	ResourceRefs []ResourceRef `json:"resourceRefs"`
+}
+
+// ResourceRef references a commit by ID
+type ResourceRef struct {
+	ID string `json:"id,omitempty"`
+}
+
+// GetCommitsResponse represents the response from Buf CommitService GetCommits API
+type GetCommitsResponse struct {
+	Commits []Commit `json:"commits,omitempty"`
+}
+
+// String renders the digest as in buf.lock, e.g. "b5:3f2a..."
+// The value is base64 in the API response and hex in the rendering
+func (d *Digest) String() string {
+	if d == nil || d.Value == "" {
+		return ""
+	}
+	value := d.Value
+	if decoded, err := base64.StdEncoding.DecodeString(value); err == nil {
+		value = hex.EncodeToString(decoded)
+	}
+	return strings.ToLower(strings.TrimPrefix(d.Type, "DIGEST_TYPE_")) + ":" + value
+}
+
+// ResolveLabel returns the ID of the commit label currently points to
+// Concurrent lookups of the same label share a single round-trip
+func (s *CommitsService) ResolveLabel(label string) (string, error) {
+	commitID, _, err := s.labelFlights.Do(label, func() (string, error) {
+		history, err := s.ListCommits(1, label, "")
+		if err != nil {
+			return "", err
+		}
+		if len(history.Values) == 0 || history.Values[0].Commit == nil || history.Values[0].Commit.ID == "" {
+			return "", fmt.Errorf("label %s has no commits", label)
+		}
+		return history.Values[0].Commit.ID, nil
+	})
+	return commitID, err
+}
+
+// ModuleDigest returns the BSR module digest of commitID, e.g. "b5:3f2a..."
+// Digests are memoized, a commit never changes
+func (s *CommitsService) ModuleDigest(commitID string) (string, error) {
+	if digest, ok := s.moduleDigests.Load(commitID); ok {
+		return digest.(string), nil
+	}
+
+	commit, err := s.GetCommit(commitID)
+	if err != nil {
+		return "", err
+	}
+	digest := commit.Digest.String()
+	if digest == "" {
+		return "", fmt.Errorf("commit %s has no digest", commitID)
+	}
+	s.moduleDigests.Store(commitID, digest)
+	return digest, nil
+}
+
+// GetCommit fetches a single commit of the module by ID
+func (s *CommitsService) GetCommit(commitID string) (*Commit, error) {
+	jsonBody, err := json.Marshal(GetCommitsRequest{ResourceRefs: []ResourceRef{{ID: commitID}}})
+	if err != nil {
+		logger.Error("Failed to marshal request body: %v", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := "https://buf.build/buf.registry.module.v1beta1.CommitService/GetCommits"
+	logger.Debug("Fetching commit from Buf: url=%s, commit=%s", url, commitID)
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		logger.Error("Failed to create HTTP request for URL %s: %v", url, err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.bsrToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.bsrToken))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		logger.Error("HTTP POST request failed for URL %s: %v", url, err)
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		logger.Debug("Commit not found in Buf (404): %s", commitID)
+		return nil, fmt.Errorf("commit not found: %s", commitID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		errorBody, _ := io.ReadAll(resp.Body)
+		logger.Error("Buf returned unexpected status code %d: %s", resp.StatusCode, string(errorBody))
+		return nil, fmt.Errorf("Buf API returned status code %d", resp.StatusCode)
+	}
+
+	var apiResponse GetCommitsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		logger.Error("Failed to unmarshal JSON response: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+	}
+	if len(apiResponse.Commits) == 0 {
+		return nil, fmt.Errorf("commit not found: %s", commitID)
+	}
+	return &apiResponse.Commits[0], nil
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"validation-service/backend/logger"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// compiledInImports are the import prefixes resolved from the descriptors compiled into the
+// binary when the proto directory and include paths do not have them
+var compiledInImports = []string{"buf/validate/", "google/protobuf/"}
+
+// CompiledDescriptorSource compiles the .proto files of a directory in-process and resolves
+// messages from the result, so edits are picked up without `buf generate` or a rebuild
+// Imports resolve from the directory, then the include paths (e.g. the output of
+// `buf export buf.build/bufbuild/protovalidate`), then the buf/validate and well-known
+// types compiled into the binary
+// Each compilation is swapped in atomically: a lookup sees either the previous or the new
+// registry, never a mix, and a failed compilation keeps the previous one
+type CompiledDescriptorSource struct {
+	dir          string
+	includePaths []string
+	compiled     atomic.Pointer[compiledFiles]
+	mu           sync.Mutex // Serializes Reload
+	fingerprint  string     // Fingerprint of the sources of the current registry, guarded by mu
+}
+
+// compiledFiles is the registry of one compilation
+type compiledFiles struct {
+	files   *protoregistry.Files
+	digests sync.Map // Message full name to descriptorDigest, a compilation never changes
+}
+
+// NewCompiledDescriptorSource compiles the .proto files in dir
+// Returns an error if they do not compile
+func NewCompiledDescriptorSource(dir string, includePaths []string) (*CompiledDescriptorSource, error) {
+	s := &CompiledDescriptorSource{dir: dir, includePaths: includePaths}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Name implements DescriptorSource
+func (s *CompiledDescriptorSource) Name() string {
+	return "compiled"
+}
+
+// FindMessage implements DescriptorSource
+func (s *CompiledDescriptorSource) FindMessage(schemaName, version string) (*ResolvedMessage, error) {
+	if err := checkLocalVersion(s.Name(), version); err != nil {
+		logger.Debug("Compiled protos cannot serve version %s of %s", version, schemaName)
+		return nil, err
+	}
+
+	compiled := s.compiled.Load()
+	md, err := findMessageInFiles(compiled.files, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("Found message descriptor in compiled protos: %s", schemaName)
+	return &ResolvedMessage{Descriptor: md, Files: compiled.files, Source: s.Name(), Commit: LocalVersion, Digest: compiled.digest(md)}, nil
+}
+
+// RangeFiles implements FileLister, the compiled files are the default version
+func (s *CompiledDescriptorSource) RangeFiles(f func(version string, files *protoregistry.Files) bool) {
+	f("", s.compiled.Load().files)
+}
+
+// Reload compiles the .proto files again and swaps in the result
+// On error the previous registry is kept
+func (s *CompiledDescriptorSource) Reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	paths, fingerprint, err := protoSources(s.dir)
+	if err != nil {
+		return err
+	}
+	files, err := s.compile(paths)
+	if err != nil {
+		return err
+	}
+	s.compiled.Store(&compiledFiles{files: files})
+	s.fingerprint = fingerprint
+	logger.Info("Compiled %d proto file(s) from %s", len(paths), s.dir)
+	return nil
+}
+
+// Watch polls the directory every interval and reloads when a .proto file is added, removed
+// or modified, until ctx is done
+// Compilation errors are logged, the previous registry keeps serving until the next change
+func (s *CompiledDescriptorSource) Watch(ctx context.Context, interval time.Duration) {
+	logger.Info("Watching %s for proto changes every %s", s.dir, interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		_, fingerprint, err := protoSources(s.dir)
+		if err != nil {
+			logger.Warn("Failed to scan %s for proto changes: %v", s.dir, err)
+			continue
+		}
+		s.mu.Lock()
+		changed := fingerprint != s.fingerprint
+		s.mu.Unlock()
+		if !changed {
+			continue
+		}
+
+		logger.Info("Proto sources changed in %s, recompiling", s.dir)
+		if err := s.Reload(); err != nil {
+			logger.Error("Failed to recompile protos, keeping the previous version: %v", err)
+			// Do not retry the same broken sources on every tick
+			s.mu.Lock()
+			s.fingerprint = fingerprint
+			s.mu.Unlock()
+		}
+	}
+}
+
+// compile compiles paths, relative to the directory, into a new registry
+func (s *CompiledDescriptorSource) compile(paths []string) (*protoregistry.Files, error) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.CompositeResolver{
+			&protocompile.SourceResolver{ImportPaths: append([]string{s.dir}, s.includePaths...)},
+			protocompile.ResolverFunc(findCompiledInImport),
+		},
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+	results, err := compiler.Compile(context.Background(), paths...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile protos in %s: %w", s.dir, err)
+	}
+
+	// Relink from the encoded descriptors, like images and BSR descriptors: the compiler
+	// holds custom options such as buf.validate rules as dynamic messages, decoding them
+	// again yields the generated types protovalidate expects
+	closure := make(map[string]protoreflect.FileDescriptor)
+	for _, result := range results {
+		collectFileClosure(result, closure)
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, fd := range closure {
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+	data, err := proto.Marshal(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode compiled protos: %w", err)
+	}
+	set = &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("failed to decode compiled protos: %w", err)
+	}
+	return newFilesFromSet(set)
+}
+
+// findCompiledInImport resolves the compiledInImports from protoregistry.GlobalFiles
+func findCompiledInImport(path string) (protocompile.SearchResult, error) {
+	if !isCompiledInImport(path) {
+		return protocompile.SearchResult{}, fs.ErrNotExist
+	}
+	fd, err := protoregistry.GlobalFiles.FindFileByPath(path)
+	if err != nil {
+		return protocompile.SearchResult{}, err
+	}
+	return protocompile.SearchResult{Desc: fd}, nil
+}
+
+// digest returns the memoized descriptorDigest of md
+func (c *compiledFiles) digest(md protoreflect.MessageDescriptor) string {
+	if digest, ok := c.digests.Load(md.FullName()); ok {
+		return digest.(string)
+	}
+	digest := descriptorDigest(md)
+	c.digests.Store(md.FullName(), digest)
+	return digest
+}
+
+// protoSources returns the .proto files under dir, relative to it and sorted, and a
+// fingerprint of their names, sizes and modification times
+// Hidden directories are skipped
+func protoSources(dir string) ([]string, string, error) {
+	var paths []string
+	hash := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".proto" {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		paths = append(paths, rel)
+		fmt.Fprintf(hash, "%s\x00%d\x00%d\n", rel, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read proto directory: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, "", fmt.Errorf("no .proto files found in %s", dir)
+	}
+	sort.Strings(paths)
+	return paths, hex.EncodeToString(hash.Sum(nil)), nil
+}
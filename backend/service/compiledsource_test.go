@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const compiledTestProto = `syntax = "proto3";
+
+package live;
+
+import "buf/validate/validate.proto";
+import "google/protobuf/timestamp.proto";
+
+// A note written by a rule author
+message Note {
+  string title = 1 [(buf.validate.field).string.min_len = %d];
+  google.protobuf.Timestamp created_at = 2;
+}
+`
+
+// writeNoteProto writes live/note.proto with the given title min_len to dir
+func writeNoteProto(t *testing.T, dir string, minLen int, modTime time.Time) {
+	t.Helper()
+	path := filepath.Join(dir, "live", "note.proto")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("Failed to create proto directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(fmt.Sprintf(compiledTestProto, minLen)), 0o644); err != nil {
+		t.Fatalf("Failed to write proto: %v", err)
+	}
+	// Filesystems with coarse timestamps would otherwise hide quick edits
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set proto modification time: %v", err)
+	}
+}
+
+// titleMinLength returns the minLength of the title in the generated schema of live.Note
+func titleMinLength(t *testing.T, source DescriptorSource) string {
+	t.Helper()
+	schema, err := NewGeneratedSchemaSource(source).GetSchema("live.Note", "", SchemaVariant{Unbundled: true})
+	if err != nil {
+		t.Fatalf("GetSchema failed: %v", err)
+	}
+	doc, err := decodeSchemaDocument(schema.Data)
+	if err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
+	}
+	return fmt.Sprint(doc["properties"].(map[string]interface{})["title"].(map[string]interface{})["minLength"])
+}
+
+func TestCompiledDescriptorSource(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(-time.Hour)
+	writeNoteProto(t, dir, 3, start)
+
+	source, err := NewCompiledDescriptorSource(dir, nil)
+	if err != nil {
+		t.Fatalf("NewCompiledDescriptorSource failed: %v", err)
+	}
+
+	resolved, err := source.FindMessage("live.Note", "main")
+	if err != nil {
+		t.Fatalf("FindMessage failed: %v", err)
+	}
+	if resolved.Source != "compiled" || resolved.Commit != LocalVersion || resolved.Files == protoregistry.GlobalFiles {
+		t.Errorf("Expected live.Note from an isolated compiled registry, got source=%s, commit=%s", resolved.Source, resolved.Commit)
+	}
+	if got := titleMinLength(t, source); got != "3" {
+		t.Errorf("Expected minLength 3, got %v", got)
+	}
+	// Source code info is kept, so generated schemas carry the comments
+	schema, _ := NewGeneratedSchemaSource(source).GetSchema("live.Note", "", SchemaVariant{Unbundled: true})
+	doc, _ := decodeSchemaDocument(schema.Data)
+	if doc["description"] != "A note written by a rule author" {
+		t.Errorf("Expected the message comment as description, got %v", doc["description"])
+	}
+
+	if _, err := source.FindMessage("live.Note", testCommitID); !errors.Is(err, ErrVersionUnavailable) {
+		t.Errorf("Expected ErrVersionUnavailable, got %v", err)
+	}
+
+	// A successful reload swaps in the edited rules
+	writeNoteProto(t, dir, 5, start.Add(time.Minute))
+	if err := source.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if got := titleMinLength(t, source); got != "5" {
+		t.Errorf("Expected minLength 5 after reload, got %v", got)
+	}
+
+	// A failed reload keeps the previous registry
+	if err := os.WriteFile(filepath.Join(dir, "live", "broken.proto"), []byte("syntax = \"proto3\"; message {"), 0o644); err != nil {
+		t.Fatalf("Failed to write proto: %v", err)
+	}
+	if err := source.Reload(); err == nil {
+		t.Error("Expected Reload to fail for a broken proto")
+	}
+	if got := titleMinLength(t, source); got != "5" {
+		t.Errorf("Expected minLength 5 to be kept, got %v", got)
+	}
+}
+
+func TestCompiledDescriptorSourceWatch(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(-time.Hour)
+	writeNoteProto(t, dir, 3, start)
+
+	source, err := NewCompiledDescriptorSource(dir, nil)
+	if err != nil {
+		t.Fatalf("NewCompiledDescriptorSource failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go source.Watch(ctx, 10*time.Millisecond)
+
+	writeNoteProto(t, dir, 7, start.Add(time.Minute))
+	deadline := time.Now().Add(5 * time.Second)
+	for titleMinLength(t, source) != "7" {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the edited proto to be recompiled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCompiledDescriptorSourceMatchesGeneratedFiles(t *testing.T) {
+	// The module root, so file names match buf's, e.g. proto/task.proto
+	source, err := NewCompiledDescriptorSource("..", nil)
+	if err != nil {
+		t.Fatalf("NewCompiledDescriptorSource failed: %v", err)
+	}
+	schemas := NewGeneratedSchemaSource(source)
+	local := NewLocalSchemaSource(filepath.Join("..", "gen", "jsonschema"))
+
+	for _, messageName := range []string{"proto.Task", "proto.SimpleUser", "proto.ComplexOrder"} {
+		for _, variant := range []SchemaVariant{{}, {JSONNames: true, Strict: true, Unbundled: true}} {
+			generated, err := schemas.GetSchema(messageName, "", variant)
+			if err != nil {
+				t.Fatalf("GetSchema %s failed: %v", messageName, err)
+			}
+			file, err := local.GetSchema(messageName, "", variant)
+			if err != nil {
+				t.Fatalf("Failed to read the generated file of %s: %v", messageName, err)
+			}
+			var got, want interface{}
+			if err := json.Unmarshal(generated.Data, &got); err != nil {
+				t.Fatalf("Failed to parse generated schema: %v", err)
+			}
+			if err := json.Unmarshal(file.Data, &want); err != nil {
+				t.Fatalf("Failed to parse schema file: %v", err)
+			}
+			// Descriptions included, the sources have comments
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Schema of %s (%s) differs from the generated file\ngot:\n%s\nwant:\n%s", messageName, variant, generated.Data, file.Data)
+			}
+		}
+	}
+}
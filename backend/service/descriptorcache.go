@@ -0,0 +1,193 @@
+package service
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+	"time"
+	"validation-service/backend/logger"
+)
+
+// commitIDPattern matches BSR commit IDs (32 lowercase hex characters)
+// Anything else (e.g. "main", "v1.2.0") is treated as a mutable label
+var commitIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// isCommitID reports whether version refers to an immutable BSR commit
+func isCommitID(version string) bool {
+	return commitIDPattern.MatchString(version)
+}
+
+// descriptorCacheKey identifies a cached descriptor set
+type descriptorCacheKey struct {
+	module string
+	commit string
+	symbol string
+}
+
+// descriptorCacheEntry holds a cached resolution and its expiry
+type descriptorCacheEntry struct {
+	key       descriptorCacheKey
+	resolved  *ResolvedMessage
+	expiresAt time.Time // Zero value means the entry never expires
+}
+
+// DescriptorCacheStats is a snapshot of the descriptor cache counters
+type DescriptorCacheStats struct {
+	Hits       uint64 `json:"hits"`
+	Misses     uint64 `json:"misses"`
+	Expired    uint64 `json:"expired"`
+	Evictions  uint64 `json:"evictions"`
+	Entries    int    `json:"entries"`
+	MaxEntries int    `json:"maxEntries"`
+	LabelTTL   string `json:"labelTtl"`
+}
+
+// DescriptorCache is an in-memory LRU cache of resolved messages (descriptor set, commit
+// and digest) keyed by (module, commit, symbol)
+// Entries for labels (e.g. "main") expire after the configured TTL,
+// entries for immutable commit IDs never expire and are only removed by LRU eviction
+type DescriptorCache struct {
+	mu         sync.Mutex
+	labelTTL   time.Duration
+	maxEntries int
+	entries    map[descriptorCacheKey]*list.Element
+	lru        *list.List       // Front is most recently used
+	now        func() time.Time // Clock for entry expiry, replaced in tests
+
+	hits      uint64
+	misses    uint64
+	expired   uint64
+	evictions uint64
+}
+
+// NewDescriptorCache creates a new descriptor cache
+// labelTTL controls how long label lookups are cached (0 disables caching for labels)
+// maxEntries bounds the number of cached descriptor sets (0 disables the cache entirely)
+func NewDescriptorCache(labelTTL time.Duration, maxEntries int) *DescriptorCache {
+	logger.Debug("Initializing DescriptorCache with labelTTL=%s, maxEntries=%d", labelTTL, maxEntries)
+	return &DescriptorCache{
+		labelTTL:   labelTTL,
+		maxEntries: maxEntries,
+		entries:    make(map[descriptorCacheKey]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get returns the cached resolution for the given module, commit and symbol
+func (c *DescriptorCache) Get(module, commit, symbol string) (*ResolvedMessage, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := descriptorCacheKey{module: module, commit: commit, symbol: symbol}
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		logger.Debug("Descriptor cache miss: module=%s, commit=%s, symbol=%s", module, commit, symbol)
+		return nil, false
+	}
+
+	entry := elem.Value.(*descriptorCacheEntry)
+	if !entry.expiresAt.IsZero() && c.now().After(entry.expiresAt) {
+		c.removeElement(elem)
+		c.expired++
+		c.misses++
+		logger.Debug("Descriptor cache entry expired: module=%s, commit=%s, symbol=%s", module, commit, symbol)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	c.hits++
+	logger.Debug("Descriptor cache hit: module=%s, commit=%s, symbol=%s", module, commit, symbol)
+	return entry.resolved, true
+}
+
+// Add stores a resolution fetched for the requested commit or label
+// resolved.Commit is the concrete commit reported by BSR (may be empty); when it is a
+// commit ID that differs from the requested one, the resolution is also cached under it
+// so later requests pinned to that commit are served without a round-trip
+func (c *DescriptorCache) Add(module, requested, symbol string, resolved *ResolvedMessage) {
+	// Stale resolutions are not cached, so the next lookup asks the source again
+	if c == nil || c.maxEntries <= 0 || resolved == nil || resolved.Files == nil || resolved.Stale {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resolvedCommit := resolved.Commit
+	c.put(descriptorCacheKey{module: module, commit: requested, symbol: symbol}, resolved)
+	if resolvedCommit != "" && resolvedCommit != requested && isCommitID(resolvedCommit) {
+		c.put(descriptorCacheKey{module: module, commit: resolvedCommit, symbol: symbol}, resolved)
+	}
+}
+
+// Stats returns a snapshot of the cache counters
+func (c *DescriptorCache) Stats() DescriptorCacheStats {
+	if c == nil {
+		return DescriptorCacheStats{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return DescriptorCacheStats{
+		Hits:       c.hits,
+		Misses:     c.misses,
+		Expired:    c.expired,
+		Evictions:  c.evictions,
+		Entries:    c.lru.Len(),
+		MaxEntries: c.maxEntries,
+		LabelTTL:   c.labelTTL.String(),
+	}
+}
+
+// put inserts or refreshes an entry and evicts the least recently used entries
+// Must be called with c.mu held
+func (c *DescriptorCache) put(key descriptorCacheKey, resolved *ResolvedMessage) {
+	var expiresAt time.Time
+	if !isCommitID(key.commit) {
+		if c.labelTTL <= 0 {
+			// Label caching disabled
+			return
+		}
+		expiresAt = c.now().Add(c.labelTTL)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*descriptorCacheEntry)
+		entry.resolved = resolved
+		entry.expiresAt = expiresAt
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&descriptorCacheEntry{
+		key:       key,
+		resolved:  resolved,
+		expiresAt: expiresAt,
+	})
+
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		if oldest == nil {
+			break
+		}
+		entry := oldest.Value.(*descriptorCacheEntry)
+		logger.Debug("Evicting descriptor cache entry: module=%s, commit=%s, symbol=%s", entry.key.module, entry.key.commit, entry.key.symbol)
+		c.removeElement(oldest)
+		c.evictions++
+	}
+}
+
+// removeElement removes an element from both the LRU list and the index
+// Must be called with c.mu held
+func (c *DescriptorCache) removeElement(elem *list.Element) {
+	entry := elem.Value.(*descriptorCacheEntry)
+	delete(c.entries, entry.key)
+	c.lru.Remove(elem)
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const testCommitID = "0123456789abcdef0123456789abcdef"
+
+// fakeClock is a controllable time source for DescriptorCache.now
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// newTestDescriptorCache creates a cache whose expiry is driven by the returned clock
+func newTestDescriptorCache(labelTTL time.Duration, maxEntries int) (*DescriptorCache, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	cache := NewDescriptorCache(labelTTL, maxEntries)
+	cache.now = clock.Now
+	return cache, clock
+}
+
+func TestDescriptorCacheCommitEntriesDoNotExpire(t *testing.T) {
+	cache, clock := newTestDescriptorCache(time.Second, 10)
+	files := &protoregistry.Files{}
+
+	cache.Add("buf.build/org/module", testCommitID, "proto.Task", &ResolvedMessage{Files: files, Commit: testCommitID})
+	clock.Advance(time.Hour)
+
+	got, ok := cache.Get("buf.build/org/module", testCommitID, "proto.Task")
+	if !ok || got.Files != files {
+		t.Fatalf("Expected commit entry to be served from cache")
+	}
+}
+
+func TestDescriptorCacheLabelEntriesExpire(t *testing.T) {
+	cache, clock := newTestDescriptorCache(time.Second, 10)
+	files := &protoregistry.Files{}
+
+	cache.Add("buf.build/org/module", "main", "proto.Task", &ResolvedMessage{Files: files, Commit: testCommitID})
+	clock.Advance(time.Second - time.Millisecond)
+	if _, ok := cache.Get("buf.build/org/module", "main", "proto.Task"); !ok {
+		t.Fatalf("Expected label entry to be served before TTL elapses")
+	}
+
+	clock.Advance(2 * time.Millisecond)
+	if _, ok := cache.Get("buf.build/org/module", "main", "proto.Task"); ok {
+		t.Fatalf("Expected label entry to expire after TTL")
+	}
+
+	// The resolved commit is cached alongside the label and outlives it
+	if _, ok := cache.Get("buf.build/org/module", testCommitID, "proto.Task"); !ok {
+		t.Fatalf("Expected resolved commit entry to remain cached")
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Expired != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestDescriptorCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewDescriptorCache(time.Minute, 2)
+
+	cache.Add("m", "main", "proto.A", &ResolvedMessage{Files: &protoregistry.Files{}})
+	cache.Add("m", "main", "proto.B", &ResolvedMessage{Files: &protoregistry.Files{}})
+	cache.Get("m", "main", "proto.A") // Touch A so B becomes the oldest
+	cache.Add("m", "main", "proto.C", &ResolvedMessage{Files: &protoregistry.Files{}})
+
+	if _, ok := cache.Get("m", "main", "proto.B"); ok {
+		t.Errorf("Expected proto.B to be evicted")
+	}
+	if _, ok := cache.Get("m", "main", "proto.A"); !ok {
+		t.Errorf("Expected proto.A to remain cached")
+	}
+	if stats := cache.Stats(); stats.Entries != 2 || stats.Evictions != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"validation-service/backend/logger"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// registeredVersionPattern matches the names descriptor sets can be registered under,
+// they are also their file names in the registry directory
+var registeredVersionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+
+// RegisteredVersionPrefix namespaces the versions of registered sets in requests, e.g.
+// version=registered/orders-v2, so a registered set never shadows a BSR commit or label
+const RegisteredVersionPrefix = "registered/"
+
+// registeredSetExtension is the extension of the persisted descriptor sets
+const registeredSetExtension = ".binpb"
+
+// DescriptorRegistry holds FileDescriptorSets registered at runtime, each under a named
+// version that is requested like a commit or label with RegisteredVersionPrefix, e.g.
+// version=registered/orders-v2
+// Each version is an isolated registry; imports of buf/validate and the well-known types
+// that a set does not include resolve from the descriptors compiled into the binary
+// Sets are persisted to a directory, if configured, and loaded again on startup
+type DescriptorRegistry struct {
+	dir      string
+	mu       sync.RWMutex
+	versions map[string]*registeredVersion
+}
+
+// registeredVersion is one registered descriptor set
+type registeredVersion struct {
+	info    RegisteredVersion
+	files   *protoregistry.Files
+	digests sync.Map // Message full name to descriptorDigest, a version is replaced, never modified
+}
+
+// RegisteredVersion describes a registered descriptor set
+type RegisteredVersion struct {
+	Version      string    `json:"version"`
+	Ref          string    `json:"ref"`      // Version to request the set at, Version with RegisteredVersionPrefix
+	Digest       string    `json:"digest"`   // Digest of the encoded set, including the compiled-in imports it was linked with
+	Files        []string  `json:"files"`    // Files of the set, excluding buf/validate and well-known types
+	Messages     []string  `json:"messages"` // Fully qualified names of the messages of Files
+	RegisteredAt time.Time `json:"registeredAt"`
+}
+
+// NewDescriptorRegistry creates a registry persisting sets to dir and loads the sets
+// persisted there; an empty dir keeps sets in memory only
+// Returns an error if a persisted set cannot be loaded
+func NewDescriptorRegistry(dir string) (*DescriptorRegistry, error) {
+	r := &DescriptorRegistry{dir: dir, versions: make(map[string]*registeredVersion)}
+	if dir == "" {
+		return r, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create descriptor registry directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor registry directory: %w", err)
+	}
+	for _, entry := range entries {
+		version, ok := strings.CutSuffix(entry.Name(), registeredSetExtension)
+		if entry.IsDir() || !ok || !registeredVersionPattern.MatchString(version) {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read registered descriptors: %w", err)
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read registered descriptors: %w", err)
+		}
+		set, err := decodeFileDescriptorSet(data, false)
+		if err != nil {
+			return nil, fmt.Errorf("registered version %s: %w", version, err)
+		}
+		registered, err := newRegisteredVersion(version, set, data, info.ModTime())
+		if err != nil {
+			return nil, fmt.Errorf("registered version %s: %w", version, err)
+		}
+		r.versions[version] = registered
+	}
+	logger.Info("Loaded %d registered descriptor version(s) from %s", len(r.versions), dir)
+	return r, nil
+}
+
+// Name implements DescriptorSource
+func (r *DescriptorRegistry) Name() string {
+	return "registered"
+}
+
+// FindMessage implements DescriptorSource
+// Versions without RegisteredVersionPrefix or that are not registered fail with
+// ErrVersionUnavailable, so a chain falls through to the other sources
+func (r *DescriptorRegistry) FindMessage(schemaName, version string) (*ResolvedMessage, error) {
+	name, ok := strings.CutPrefix(version, RegisteredVersionPrefix)
+	if !ok {
+		return nil, fmt.Errorf("%w: version %s is not a registered version", ErrVersionUnavailable, version)
+	}
+	r.mu.RLock()
+	registered, ok := r.versions[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: version %s is not registered", ErrVersionUnavailable, version)
+	}
+
+	md, err := findMessageInFiles(registered.files, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("Found message descriptor in registered version %s: %s", version, schemaName)
+	return &ResolvedMessage{Descriptor: md, Files: registered.files, Source: r.Name(), Commit: registered.info.Ref, Digest: registered.digest(md)}, nil
+}
+
+// Register links the FileDescriptorSet in data, binary or JSON, and registers it under
+// version, replacing the set registered under it before, if any
+// Returns an error wrapping ErrInvalidArgument if version is not a valid name, e.g. a
+// commit ID, or the set cannot be decoded or does not link
+func (r *DescriptorRegistry) Register(version string, data []byte, jsonEncoded bool) (*RegisteredVersion, error) {
+	if !registeredVersionPattern.MatchString(version) {
+		return nil, fmt.Errorf("%w: version must match %s", ErrInvalidArgument, registeredVersionPattern)
+	}
+	if localVersions[version] {
+		return nil, fmt.Errorf("%w: version %s is reserved for the local sources", ErrInvalidArgument, version)
+	}
+	if isCommitID(version) {
+		return nil, fmt.Errorf("%w: version %s is a commit ID", ErrInvalidArgument, version)
+	}
+
+	set, err := decodeFileDescriptorSet(data, jsonEncoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+	}
+	if len(set.GetFile()) == 0 {
+		return nil, fmt.Errorf("%w: the FileDescriptorSet has no files", ErrInvalidArgument)
+	}
+	if err := addCompiledInImports(set); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+	}
+	// Persist the linked set, so it loads the same way regardless of the encoding it was sent in
+	encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode descriptors: %w", err)
+	}
+	registered, err := newRegisteredVersion(version, set, encoded, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.dir != "" {
+		if err := writeFileAtomic(filepath.Join(r.dir, version+registeredSetExtension), encoded); err != nil {
+			return nil, fmt.Errorf("failed to persist descriptors: %w", err)
+		}
+	}
+	r.versions[version] = registered
+	logger.Info("Registered descriptor version %s with %d file(s) and %d message(s)", version, len(registered.info.Files), len(registered.info.Messages))
+	info := registered.info
+	return &info, nil
+}
+
+// Delete removes the set registered under version
+// Returns an error wrapping ErrNotFound if no set is registered under it
+func (r *DescriptorRegistry) Delete(version string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.versions[version]; !ok {
+		return fmt.Errorf("registered version %s %w", version, ErrNotFound)
+	}
+	if r.dir != "" {
+		err := os.Remove(filepath.Join(r.dir, version+registeredSetExtension))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete persisted descriptors: %w", err)
+		}
+	}
+	delete(r.versions, version)
+	logger.Info("Deleted registered descriptor version %s", version)
+	return nil
+}
+
+// Get describes the set registered under version
+// Returns an error wrapping ErrNotFound if no set is registered under it
+func (r *DescriptorRegistry) Get(version string) (*RegisteredVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	registered, ok := r.versions[version]
+	if !ok {
+		return nil, fmt.Errorf("registered version %s %w", version, ErrNotFound)
+	}
+	info := registered.info
+	return &info, nil
+}
+
+// List describes the registered sets, sorted by version
+func (r *DescriptorRegistry) List() []RegisteredVersion {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	versions := make([]RegisteredVersion, 0, len(r.versions))
+	for _, registered := range r.versions {
+		versions = append(versions, registered.info)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	return versions
+}
+
+// RangeFiles implements FileLister, every registered version is listed under its Ref
+func (r *DescriptorRegistry) RangeFiles(f func(version string, files *protoregistry.Files) bool) {
+	for _, info := range r.List() {
+		r.mu.RLock()
+		registered, ok := r.versions[info.Version]
+		r.mu.RUnlock()
+		if ok && !f(info.Ref, registered.files) {
+			return
+		}
+	}
+}
+
+// newRegisteredVersion links set, encoded as data, into a registry
+func newRegisteredVersion(version string, set *descriptorpb.FileDescriptorSet, data []byte, registeredAt time.Time) (*registeredVersion, error) {
+	files, err := newFilesFromSet(set)
+	if err != nil {
+		return nil, err
+	}
+
+	info := RegisteredVersion{Version: version, Ref: RegisteredVersionPrefix + version, Digest: contentDigest(data), Files: []string{}, Messages: []string{}, RegisteredAt: registeredAt.UTC()}
+	for _, file := range set.GetFile() {
+		if isCompiledInImport(file.GetName()) {
+			continue
+		}
+		info.Files = append(info.Files, file.GetName())
+		fd, err := files.FindFileByPath(file.GetName())
+		if err != nil {
+			return nil, err
+		}
+		info.Messages = appendMessageNames(info.Messages, fd.Messages())
+	}
+	sort.Strings(info.Files)
+	sort.Strings(info.Messages)
+	return &registeredVersion{info: info, files: files}, nil
+}
+
+// digest returns the memoized descriptorDigest of md
+func (v *registeredVersion) digest(md protoreflect.MessageDescriptor) string {
+	if digest, ok := v.digests.Load(md.FullName()); ok {
+		return digest.(string)
+	}
+	digest := descriptorDigest(md)
+	v.digests.Store(md.FullName(), digest)
+	return digest
+}
+
+// appendMessageNames appends the full names of messages and their nested messages,
+// except map entries
+func appendMessageNames(names []string, messages protoreflect.MessageDescriptors) []string {
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		if md.IsMapEntry() {
+			continue
+		}
+		names = append(names, string(md.FullName()))
+		names = appendMessageNames(names, md.Messages())
+	}
+	return names
+}
+
+// isCompiledInImport reports whether path is one of the compiledInImports
+func isCompiledInImport(path string) bool {
+	for _, prefix := range compiledInImports {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// addCompiledInImports adds the compiledInImports that files of set import but set does
+// not include, from protoregistry.GlobalFiles
+func addCompiledInImports(set *descriptorpb.FileDescriptorSet) error {
+	included := make(map[string]bool)
+	for _, file := range set.GetFile() {
+		included[file.GetName()] = true
+	}
+	missing := make(map[string]protoreflect.FileDescriptor)
+	for _, file := range set.GetFile() {
+		for _, dep := range file.GetDependency() {
+			if included[dep] || !isCompiledInImport(dep) {
+				continue
+			}
+			fd, err := protoregistry.GlobalFiles.FindFileByPath(dep)
+			if err != nil {
+				return fmt.Errorf("import %s of %s: %w", dep, file.GetName(), err)
+			}
+			collectFileClosure(fd, missing)
+		}
+	}
+
+	paths := make([]string, 0, len(missing))
+	for path := range missing {
+		if !included[path] {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(missing[path]))
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to path through a temporary file, so a crash never leaves
+// a partially written file behind
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
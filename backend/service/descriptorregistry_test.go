@@ -0,0 +1,123 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// orderSet returns a FileDescriptorSet of orders.Order with the given fields, importing
+// buf/validate without including it
+func orderSet(fields ...string) *descriptorpb.FileDescriptorSet {
+	message := &descriptorpb.DescriptorProto{Name: proto.String("Order")}
+	for i, name := range fields {
+		message.Field = append(message.Field, &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(int32(i + 1)),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+		})
+	}
+	return &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:        proto.String("orders/order.proto"),
+		Package:     proto.String("orders"),
+		Syntax:      proto.String("proto3"),
+		Dependency:  []string{"buf/validate/validate.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{message},
+	}}}
+}
+
+func TestDescriptorRegistry(t *testing.T) {
+	dir := t.TempDir()
+	registry, err := NewDescriptorRegistry(dir)
+	if err != nil {
+		t.Fatalf("NewDescriptorRegistry failed: %v", err)
+	}
+
+	v1, _ := proto.Marshal(orderSet("id"))
+	registered, err := registry.Register("orders-v1", v1, false)
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if !reflect.DeepEqual(registered.Files, []string{"orders/order.proto"}) || !reflect.DeepEqual(registered.Messages, []string{"orders.Order"}) {
+		t.Errorf("Expected only the registered file and message to be described, got %v, %v", registered.Files, registered.Messages)
+	}
+	v2, _ := protojson.Marshal(orderSet("id", "note"))
+	if _, err := registry.Register("orders-v2", v2, true); err != nil {
+		t.Fatalf("Register failed for a JSON set: %v", err)
+	}
+
+	// Each version is isolated and reported as the commit
+	for version, fields := range map[string]int{"registered/orders-v1": 1, "registered/orders-v2": 2} {
+		resolved, err := registry.FindMessage("orders.Order", version)
+		if err != nil {
+			t.Fatalf("FindMessage %s failed: %v", version, err)
+		}
+		if resolved.Commit != version || resolved.Source != "registered" || resolved.Descriptor.Fields().Len() != fields {
+			t.Errorf("Expected %d field(s) at commit %s, got %d at commit %s", fields, version, resolved.Descriptor.Fields().Len(), resolved.Commit)
+		}
+	}
+	for _, version := range []string{"main", "registered/main", "orders-v1"} {
+		if _, err := registry.FindMessage("orders.Order", version); !errors.Is(err, ErrVersionUnavailable) {
+			t.Errorf("Version %q: expected ErrVersionUnavailable, got %v", version, err)
+		}
+	}
+
+	// Registered sets are loaded again from the directory
+	reloaded, err := NewDescriptorRegistry(dir)
+	if err != nil {
+		t.Fatalf("NewDescriptorRegistry failed to reload: %v", err)
+	}
+	if versions := reloaded.List(); len(versions) != 2 || versions[0].Digest != registered.Digest {
+		t.Errorf("Expected both versions to be reloaded unchanged, got %+v", versions)
+	}
+
+	if err := reloaded.Delete("orders-v1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := reloaded.Delete("orders-v1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a deleted version, got %v", err)
+	}
+	reloaded, _ = NewDescriptorRegistry(dir)
+	if versions := reloaded.List(); len(versions) != 1 || versions[0].Version != "orders-v2" {
+		t.Errorf("Expected only orders-v2 to remain, got %+v", versions)
+	}
+}
+
+func TestDescriptorRegistryRejectsInvalidSets(t *testing.T) {
+	registry, _ := NewDescriptorRegistry("")
+	valid, _ := proto.Marshal(orderSet("id"))
+
+	unlinked := orderSet("id")
+	unlinked.File[0].Dependency = append(unlinked.File[0].Dependency, "orders/missing.proto")
+	unlinkedData, _ := proto.Marshal(unlinked)
+
+	tests := []struct {
+		name    string
+		version string
+		data    []byte
+	}{
+		{"reserved version", LocalVersion, valid},
+		{"default label", "main", valid},
+		{"path in version", "../orders", valid},
+		{"commit ID", testCommitID, valid},
+		{"undecodable set", "orders-v1", []byte("not a descriptor set")},
+		{"missing import", "orders-v1", unlinkedData},
+		{"empty set", "orders-v1", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := registry.Register(tt.version, tt.data, false); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("Expected ErrInvalidArgument, got %v", err)
+			}
+		})
+	}
+	if versions := registry.List(); len(versions) != 0 {
+		t.Errorf("Expected nothing to be registered, got %+v", versions)
+	}
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"golang.org/x/sync/singleflight"
+)
+
+// flightGroup coalesces concurrent calls that share a key so that only one
+// upstream request runs at a time; every waiter receives the same result and error
+// It is a typed wrapper of singleflight.Group
+type flightGroup[T any] struct {
+	group singleflight.Group
+}
+
+// Do executes fn for key, or waits for the already running call for key to finish
+// shared reports whether the result was delivered to more than one caller
+func (g *flightGroup[T]) Do(key string, fn func() (T, error)) (val T, shared bool, err error) {
+	v, err, shared := g.group.Do(key, func() (interface{}, error) {
+		return fn()
+	})
+	val, _ = v.(T)
+	return val, shared, err
+}
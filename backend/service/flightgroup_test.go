@@ -0,0 +1,64 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlightGroupCoalescesConcurrentCalls(t *testing.T) {
+	var group flightGroup[string]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	wantErr := errors.New("upstream failed")
+
+	const callers = 10
+	var started, done sync.WaitGroup
+	started.Add(callers)
+	done.Add(callers)
+
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		go func(i int) {
+			defer done.Done()
+			started.Done()
+			_, _, errs[i] = group.Do("proto.Task", func() (string, error) {
+				calls.Add(1)
+				<-release
+				return "", wantErr
+			})
+		}(i)
+	}
+
+	// Give every caller a chance to join the in-flight call before releasing it
+	started.Wait()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Expected 1 upstream call, got %d", got)
+	}
+	for i, err := range errs {
+		if !errors.Is(err, wantErr) {
+			t.Errorf("Caller %d: expected shared error %v, got %v", i, wantErr, err)
+		}
+	}
+}
+
+func TestFlightGroupRunsAgainAfterCompletion(t *testing.T) {
+	var group flightGroup[int]
+	calls := 0
+
+	for i := 0; i < 3; i++ {
+		val, shared, err := group.Do("key", func() (int, error) {
+			calls++
+			return calls, nil
+		})
+		if err != nil || shared || val != i+1 {
+			t.Fatalf("Call %d: unexpected result val=%d, err=%v, shared=%v", i, val, err, shared)
+		}
+	}
+}
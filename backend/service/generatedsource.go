@@ -0,0 +1,47 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"validation-service/backend/logger"
+)
+
+// GeneratedSchemaSource generates JSON Schema documents from the descriptors another source
+// resolves, so every message that can be validated has a schema, including messages of
+// commits no generated archive exists for
+// The schema reports the commit of the descriptors it was generated from
+type GeneratedSchemaSource struct {
+	descriptors DescriptorSource
+}
+
+// NewGeneratedSchemaSource creates a source generating schemas from the messages of descriptors
+func NewGeneratedSchemaSource(descriptors DescriptorSource) *GeneratedSchemaSource {
+	return &GeneratedSchemaSource{descriptors: descriptors}
+}
+
+// Name implements SchemaSource
+func (s *GeneratedSchemaSource) Name() string {
+	return "generated"
+}
+
+// GetSchema implements SchemaSource
+func (s *GeneratedSchemaSource) GetSchema(messageName, version string, variant SchemaVariant) (*ResolvedSchema, error) {
+	resolved, err := s.descriptors.FindMessage(messageName, version)
+	if errors.Is(err, ErrInvalidArgument) {
+		// Only messages have a schema, e.g. an enum of the same name does not
+		logger.Debug("Cannot generate a schema for %s: %v", messageName, err)
+		return nil, fmt.Errorf("schema for %s %w, it is not a message", messageName, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := generateJSONSchema(resolved.Descriptor, variant)
+	if err != nil {
+		logger.Error("Failed to generate schema for %s: %v", messageName, err)
+		return nil, fmt.Errorf("failed to generate schema for %s: %w", messageName, err)
+	}
+
+	logger.Debug("Generated schema for %s from source=%s (commit=%s, variant=%s)", messageName, resolved.Source, resolved.Commit, variant)
+	return &ResolvedSchema{Data: data, Source: s.Name(), Commit: resolved.Commit, ModuleDigest: resolved.ModuleDigest}, nil
+}
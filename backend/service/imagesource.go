@@ -0,0 +1,247 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"validation-service/backend/logger"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// imageExtensions are the file extensions of FileDescriptorSet images, e.g. the output of
+// `buf build -o image.binpb`, and whether they are in the JSON encoding
+var imageExtensions = map[string]bool{
+	".binpb": false,
+	".bin":   false,
+	".pb":    false,
+	".json":  true,
+}
+
+// ImageDescriptorSource resolves messages from FileDescriptorSet images on disk
+// path is an image file, or a directory: images directly in it are the default version
+// (LocalVersion), every subdirectory holding images is a version named after it, e.g.
+// a commit ID or label; each version is an isolated registry
+// Images must include their imports, i.e. not be built with --exclude-imports
+type ImageDescriptorSource struct {
+	path     string
+	versions map[string]*imageVersion
+}
+
+// imageVersion is the registry of one version of the images
+type imageVersion struct {
+	files   *protoregistry.Files
+	digests sync.Map // Message full name to descriptorDigest, the images never change
+}
+
+// NewImageDescriptorSource loads the images at path, see ImageDescriptorSource
+// Returns an error if an image cannot be read or its files do not link
+func NewImageDescriptorSource(path string) (*ImageDescriptorSource, error) {
+	logger.Debug("Loading FileDescriptorSet images from %s", path)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read images: %w", err)
+	}
+
+	source := &ImageDescriptorSource{path: path, versions: make(map[string]*imageVersion)}
+	if !info.IsDir() {
+		files, err := loadImages([]string{path})
+		if err != nil {
+			return nil, err
+		}
+		source.versions[LocalVersion] = &imageVersion{files: files}
+		return source, nil
+	}
+
+	if err := source.loadVersion(LocalVersion, path); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image directory: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			if err := source.loadVersion(entry.Name(), filepath.Join(path, entry.Name())); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(source.versions) == 0 {
+		return nil, fmt.Errorf("no FileDescriptorSet images found in %s", path)
+	}
+	logger.Info("Loaded FileDescriptorSet images from %s, versions: %s", path, strings.Join(source.Versions(), ", "))
+	return source, nil
+}
+
+// loadVersion loads the images directly in dir as version, if there are any
+func (s *ImageDescriptorSource) loadVersion(version, dir string) error {
+	paths, err := imagePaths(dir)
+	if err != nil || len(paths) == 0 {
+		return err
+	}
+	files, err := loadImages(paths)
+	if err != nil {
+		return fmt.Errorf("version %s: %w", version, err)
+	}
+	s.versions[version] = &imageVersion{files: files}
+	logger.Debug("Loaded %d image(s) for version %s from %s", len(paths), version, dir)
+	return nil
+}
+
+// Name implements DescriptorSource
+func (s *ImageDescriptorSource) Name() string {
+	return "image"
+}
+
+// Versions returns the names of the loaded versions, sorted
+func (s *ImageDescriptorSource) Versions() []string {
+	versions := make([]string, 0, len(s.versions))
+	for version := range s.versions {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// RangeFiles implements FileLister, the images in the directory itself are the default version
+func (s *ImageDescriptorSource) RangeFiles(f func(version string, files *protoregistry.Files) bool) {
+	for _, version := range s.Versions() {
+		name := version
+		if version == LocalVersion {
+			name = ""
+		}
+		if !f(name, s.versions[version].files) {
+			return
+		}
+	}
+}
+
+// FindMessage implements DescriptorSource
+// The default version serves "", "main" and LocalVersion, unless a "main" directory exists
+func (s *ImageDescriptorSource) FindMessage(schemaName, version string) (*ResolvedMessage, error) {
+	name, images, err := s.version(version)
+	if err != nil {
+		logger.Debug("Images cannot serve version %s of %s", version, schemaName)
+		return nil, err
+	}
+
+	md, err := findMessageInFiles(images.files, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("Found message descriptor in images: %s (version %s)", schemaName, name)
+	return &ResolvedMessage{Descriptor: md, Files: images.files, Source: s.Name(), Commit: name, Digest: images.digest(md)}, nil
+}
+
+// version returns the name and registry of the version serving version
+func (s *ImageDescriptorSource) version(version string) (string, *imageVersion, error) {
+	if images, ok := s.versions[version]; ok {
+		return version, images, nil
+	}
+	if version == "" {
+		if images, ok := s.versions["main"]; ok {
+			return "main", images, nil
+		}
+	}
+	if images, ok := s.versions[LocalVersion]; ok && localVersions[version] {
+		return LocalVersion, images, nil
+	}
+	return "", nil, fmt.Errorf("%w: commit %s cannot be served by the %s source, it has versions %s", ErrVersionUnavailable, version, s.Name(), strings.Join(s.Versions(), ", "))
+}
+
+// digest returns the memoized descriptorDigest of md
+func (v *imageVersion) digest(md protoreflect.MessageDescriptor) string {
+	if digest, ok := v.digests.Load(md.FullName()); ok {
+		return digest.(string)
+	}
+	digest := descriptorDigest(md)
+	v.digests.Store(md.FullName(), digest)
+	return digest
+}
+
+// imagePaths returns the image files directly in dir, sorted
+func imagePaths(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image directory: %w", err)
+	}
+	var paths []string
+	for _, entry := range entries {
+		if _, ok := imageExtensions[filepath.Ext(entry.Name())]; ok && !entry.IsDir() {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return paths, nil
+}
+
+// loadImages reads the images at paths into a single registry
+// A file present in several images must be identical in all of them
+func loadImages(paths []string) (*protoregistry.Files, error) {
+	merged := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]*descriptorpb.FileDescriptorProto)
+	for _, path := range paths {
+		set, err := readImage(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range set.GetFile() {
+			if previous, ok := seen[file.GetName()]; ok {
+				if !proto.Equal(previous, file) {
+					return nil, fmt.Errorf("image %s: file %s differs from another image", path, file.GetName())
+				}
+				continue
+			}
+			seen[file.GetName()] = file
+			merged.File = append(merged.File, file)
+		}
+	}
+	return newFilesFromSet(merged)
+}
+
+// readImage decodes the FileDescriptorSet image at path, binary or JSON by its extension
+func readImage(path string) (*descriptorpb.FileDescriptorSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	set, err := decodeFileDescriptorSet(data, imageExtensions[filepath.Ext(path)])
+	if err != nil {
+		return nil, fmt.Errorf("image %s: %w", path, err)
+	}
+	return set, nil
+}
+
+// decodeFileDescriptorSet decodes a FileDescriptorSet in the binary or JSON encoding
+// Fields buf adds to images (e.g. buf_extension) are ignored
+func decodeFileDescriptorSet(data []byte, jsonEncoded bool) (*descriptorpb.FileDescriptorSet, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	var err error
+	if jsonEncoded {
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, set)
+	} else {
+		err = proto.Unmarshal(data, set)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode FileDescriptorSet: %w", err)
+	}
+	return set, nil
+}
+
+// newFilesFromSet links the files of set into a new registry
+// Every import must be part of set
+func newFilesFromSet(set *descriptorpb.FileDescriptorSet) (*protoregistry.Files, error) {
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link descriptors (images must include their imports): %w", err)
+	}
+	return files, nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// imageOf returns a FileDescriptorSet image of the file defining messageName in files and its imports
+func imageOf(t *testing.T, files *protoregistry.Files, messageName string) *descriptorpb.FileDescriptorSet {
+	t.Helper()
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(messageName))
+	if err != nil {
+		t.Fatalf("Failed to find %s: %v", messageName, err)
+	}
+	closure := make(map[string]protoreflect.FileDescriptor)
+	collectFileClosure(desc.ParentFile(), closure)
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, fd := range closure {
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+	return set
+}
+
+// writeImage writes set to path, in JSON if path ends in .json
+func writeImage(t *testing.T, path string, set *descriptorpb.FileDescriptorSet) {
+	t.Helper()
+	var data []byte
+	var err error
+	if filepath.Ext(path) == ".json" {
+		data, err = protojson.Marshal(set)
+	} else {
+		data, err = proto.Marshal(set)
+	}
+	if err != nil {
+		t.Fatalf("Failed to marshal image: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("Failed to create image directory: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("Failed to write image: %v", err)
+	}
+}
+
+func TestImageDescriptorSourceVersions(t *testing.T) {
+	dir := t.TempDir()
+	writeImage(t, filepath.Join(dir, "image.binpb"), imageOf(t, protoregistry.GlobalFiles, "proto.Task"))
+	writeImage(t, filepath.Join(dir, "v2", "image.json"), imageOf(t, isolatedTaskFiles(t), "proto.Task"))
+
+	source, err := NewImageDescriptorSource(dir)
+	if err != nil {
+		t.Fatalf("NewImageDescriptorSource failed: %v", err)
+	}
+
+	for _, version := range []string{"", "main", LocalVersion} {
+		resolved, err := source.FindMessage("proto.Task", version)
+		if err != nil {
+			t.Fatalf("Version %q: FindMessage failed: %v", version, err)
+		}
+		if resolved.Commit != LocalVersion || resolved.Source != "image" || resolved.Descriptor.Fields().ByName("status") == nil {
+			t.Errorf("Version %q: expected the default image's proto.Task, got commit=%s, source=%s", version, resolved.Commit, resolved.Source)
+		}
+		// The image registry is isolated from the compiled-in types
+		if resolved.Files == protoregistry.GlobalFiles {
+			t.Errorf("Version %q: expected an isolated registry", version)
+		}
+	}
+
+	resolved, err := source.FindMessage("proto.Task", "v2")
+	if err != nil {
+		t.Fatalf("FindMessage failed: %v", err)
+	}
+	if resolved.Commit != "v2" || resolved.Descriptor.Fields().ByName("extra") == nil {
+		t.Errorf("Expected the v2 image's proto.Task, got commit=%s", resolved.Commit)
+	}
+
+	if _, err := source.FindMessage("proto.Task", testCommitID); !errors.Is(err, ErrVersionUnavailable) {
+		t.Errorf("Expected ErrVersionUnavailable, got %v", err)
+	}
+	if _, err := source.FindMessage("proto.SimpleUser", "v2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestImageDescriptorSourceRejectsUnlinkedImages(t *testing.T) {
+	set := imageOf(t, protoregistry.GlobalFiles, "proto.Task")
+	for i, file := range set.File {
+		if file.GetName() == "proto/task.proto" {
+			set.File = []*descriptorpb.FileDescriptorProto{set.File[i]}
+			break
+		}
+	}
+	path := filepath.Join(t.TempDir(), "image.binpb")
+	writeImage(t, path, set)
+
+	if _, err := NewImageDescriptorSource(path); err == nil {
+		t.Error("Expected an image without its imports to be rejected")
+	}
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Rule ids reported for JSON values that cannot be decoded into their protobuf field
+const (
+	jsonTypeMismatchRuleID  = "json.type_mismatch"  // e.g. a string where an integer is expected
+	jsonOutOfRangeRuleID    = "json.out_of_range"   // e.g. 5000000000 for an int32 field
+	jsonInvalidFormatRuleID = "json.invalid_format" // e.g. bad base64 or a malformed Timestamp
+	jsonInvalidEnumRuleID   = "json.invalid_enum"   // enum name that is not defined
+	jsonOneofConflictRuleID = "json.oneof_conflict" // two fields of the same oneof are set
+	jsonInvalidValueRuleID  = "json.invalid_value"  // any other decode failure
+)
+
+// jsonProblem describes why a single JSON value cannot be decoded
+type jsonProblem struct {
+	ruleID      string
+	description string // e.g. "expected an integer but got string \"abc\""
+}
+
+// report appends the problem as a ValidationError located at loc
+func (p *jsonProblem) report(c *jsonPayloadChecker, loc jsonLocation, value interface{}) {
+	label := loc.jsonField
+	if label == "" {
+		label = "payload"
+	}
+
+	validationError := ValidationError{
+		Friendly:  fmt.Sprintf("field '%s': %s", label, p.description),
+		Technical: fmt.Sprintf("%s: %s", label, p.description),
+		Field:     loc.field,
+		JSONField: loc.jsonField,
+		Pointer:   pointerTo(loc.pointer),
+		RuleID:    p.ruleID,
+	}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		// Only scalar values are echoed back, objects and arrays may be arbitrarily large
+	default:
+		validationError.Value = value
+	}
+	c.errors = append(c.errors, validationError)
+}
+
+// addTypeMismatch reports a value of the wrong JSON type at loc
+func (c *jsonPayloadChecker) addTypeMismatch(loc jsonLocation, expected string, value interface{}) {
+	typeMismatch(expected, value).report(c, loc, value)
+}
+
+// checkWellKnownType checks a value of a well-known type by decoding it with protojson,
+// which owns their special JSON mappings (RFC 3339 timestamps, "1.5s" durations, etc.)
+func (c *jsonPayloadChecker) checkWellKnownType(md protoreflect.MessageDescriptor, value interface{}, loc jsonLocation) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+
+	unmarshalOpts := protojson.UnmarshalOptions{DiscardUnknown: true, Resolver: c.resolver}
+	if err := unmarshalOpts.Unmarshal(data, dynamicpb.NewMessage(md)); err != nil {
+		problem := &jsonProblem{
+			ruleID:      jsonInvalidFormatRuleID,
+			description: fmt.Sprintf("%s is not a valid %s", describeJSONValue(value), md.FullName()),
+		}
+		problem.report(c, loc, value)
+	}
+}
+
+// acceptsJSONNull reports whether null is a value of fd rather than "not set"
+func acceptsJSONNull(fd protoreflect.FieldDescriptor) bool {
+	if fd.IsList() || fd.IsMap() {
+		return false
+	}
+	if fd.Message() != nil {
+		return fd.Message().FullName() == "google.protobuf.Value"
+	}
+	return fd.Enum() != nil && fd.Enum().FullName() == "google.protobuf.NullValue"
+}
+
+// checkJSONScalar checks a JSON value against a scalar or enum field, following the
+// protojson mapping (64-bit integers and floats may be quoted, bytes are base64, etc.)
+// Returns nil if protojson accepts the value
+func checkJSONScalar(fd protoreflect.FieldDescriptor, value interface{}) *jsonProblem {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if _, ok := value.(bool); !ok {
+			return typeMismatch("a boolean", value)
+		}
+	case protoreflect.StringKind:
+		if _, ok := value.(string); !ok {
+			return typeMismatch("a string", value)
+		}
+	case protoreflect.BytesKind:
+		s, ok := value.(string)
+		if !ok {
+			return typeMismatch("a base64 encoded string", value)
+		}
+		if !isBase64(s) {
+			return &jsonProblem{ruleID: jsonInvalidFormatRuleID, description: fmt.Sprintf("%s is not valid base64", describeJSONValue(value))}
+		}
+	case protoreflect.EnumKind:
+		switch v := value.(type) {
+		case nil:
+			if fd.Enum().FullName() != "google.protobuf.NullValue" {
+				return typeMismatch("an enum name or number", value)
+			}
+		case string:
+			// Undefined names are dropped by protojson like unknown fields, see findUndefinedJSONEnums
+		case json.Number:
+			return checkJSONInteger(string(v), value, 32, true)
+		default:
+			return typeMismatch("an enum name or number", value)
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return checkJSONNumber(value, 32, true, checkJSONInteger)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return checkJSONNumber(value, 32, false, checkJSONInteger)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return checkJSONNumber(value, 64, true, checkJSONInteger)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return checkJSONNumber(value, 64, false, checkJSONInteger)
+	case protoreflect.FloatKind:
+		return checkJSONNumber(value, 32, true, checkJSONFloat)
+	case protoreflect.DoubleKind:
+		return checkJSONNumber(value, 64, true, checkJSONFloat)
+	}
+	return nil
+}
+
+// checkJSONNumber extracts the text of a numeric value, which protojson accepts either as a
+// JSON number or as a quoted string, and checks it with check
+func checkJSONNumber(value interface{}, bits int, signed bool, check func(s string, value interface{}, bits int, signed bool) *jsonProblem) *jsonProblem {
+	switch v := value.(type) {
+	case json.Number:
+		return check(string(v), value, bits, signed)
+	case string:
+		return check(v, value, bits, signed)
+	default:
+		return typeMismatch("a number", value)
+	}
+}
+
+// checkJSONInteger checks that s is an integer that fits in the given size
+// Like protojson, integral values written with a fraction or exponent (e.g. "1.0", "1e3") are accepted
+func checkJSONInteger(s string, value interface{}, bits int, signed bool) *jsonProblem {
+	kind := integerKindName(bits, signed)
+
+	var err error
+	if signed {
+		_, err = strconv.ParseInt(s, 10, bits)
+	} else {
+		_, err = strconv.ParseUint(s, 10, bits)
+	}
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return outOfRange(value, kind)
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return typeMismatch("an integer", value)
+	}
+	if f != math.Trunc(f) {
+		return typeMismatch("an integer", value)
+	}
+
+	var min, max float64
+	switch {
+	case signed && bits == 32:
+		min, max = math.MinInt32, math.MaxInt32
+	case signed:
+		min, max = math.MinInt64, math.MaxInt64
+	case bits == 32:
+		max = math.MaxUint32
+	default:
+		max = math.MaxUint64
+	}
+	if f < min || f > max {
+		return outOfRange(value, kind)
+	}
+	return nil
+}
+
+// checkJSONFloat checks that s is a number that fits in a float (bits 32) or double (bits 64)
+func checkJSONFloat(s string, value interface{}, bits int, _ bool) *jsonProblem {
+	if _, isString := value.(string); isString {
+		switch s {
+		case "NaN", "Infinity", "-Infinity":
+			return nil
+		}
+	}
+
+	if _, err := strconv.ParseFloat(s, bits); err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			kind := "double"
+			if bits == 32 {
+				kind = "float"
+			}
+			return outOfRange(value, kind)
+		}
+		return typeMismatch("a number", value)
+	}
+	return nil
+}
+
+// checkJSONMapKey checks a JSON object key against the key type of a map field
+func checkJSONMapKey(fd protoreflect.FieldDescriptor, key string) *jsonProblem {
+	var err error
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if key != "true" && key != "false" {
+			err = strconv.ErrSyntax
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		_, err = strconv.ParseInt(key, 10, 32)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		_, err = strconv.ParseInt(key, 10, 64)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		_, err = strconv.ParseUint(key, 10, 32)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		_, err = strconv.ParseUint(key, 10, 64)
+	}
+	if err != nil {
+		return &jsonProblem{ruleID: jsonTypeMismatchRuleID, description: fmt.Sprintf("map key %q is not a valid %s", key, fd.Kind())}
+	}
+	return nil
+}
+
+// typeMismatch describes a value of the wrong JSON type
+func typeMismatch(expected string, value interface{}) *jsonProblem {
+	return &jsonProblem{ruleID: jsonTypeMismatchRuleID, description: fmt.Sprintf("expected %s but got %s", expected, describeJSONValue(value))}
+}
+
+// outOfRange describes a number that does not fit in its field type
+func outOfRange(value interface{}, kind string) *jsonProblem {
+	return &jsonProblem{ruleID: jsonOutOfRangeRuleID, description: fmt.Sprintf("%s is out of range for %s", describeJSONValue(value), kind)}
+}
+
+// integerKindName returns the protobuf name of an integer type, e.g. "int32" or "uint64"
+func integerKindName(bits int, signed bool) string {
+	if signed {
+		return "int" + strconv.Itoa(bits)
+	}
+	return "uint" + strconv.Itoa(bits)
+}
+
+// isBase64 reports whether s is standard or URL-safe base64, padded or not, as accepted by protojson
+func isBase64(s string) bool {
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if _, err := encoding.DecodeString(s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// describeJSONValue renders a decoded JSON value for error messages, e.g. `string "abc"`
+func describeJSONValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean " + strconv.FormatBool(v)
+	case json.Number:
+		return "number " + v.String()
+	case string:
+		return "string " + strconv.Quote(v)
+	case []interface{}:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
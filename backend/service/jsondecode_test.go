@@ -0,0 +1,78 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestCheckJSONScalarMatchesProtojson(t *testing.T) {
+	tests := []struct {
+		name       string
+		msg        proto.Message
+		value      string
+		wantRuleID string
+	}{
+		{name: "int32 number", msg: &wrapperspb.Int32Value{}, value: `42`},
+		{name: "int32 quoted", msg: &wrapperspb.Int32Value{}, value: `"42"`},
+		{name: "int32 exponent", msg: &wrapperspb.Int32Value{}, value: `1e3`},
+		{name: "int32 fraction", msg: &wrapperspb.Int32Value{}, value: `1.5`, wantRuleID: jsonTypeMismatchRuleID},
+		{name: "int32 word", msg: &wrapperspb.Int32Value{}, value: `"abc"`, wantRuleID: jsonTypeMismatchRuleID},
+		{name: "int32 overflow", msg: &wrapperspb.Int32Value{}, value: `5000000000`, wantRuleID: jsonOutOfRangeRuleID},
+		{name: "int32 boolean", msg: &wrapperspb.Int32Value{}, value: `true`, wantRuleID: jsonTypeMismatchRuleID},
+		{name: "uint32 negative", msg: &wrapperspb.UInt32Value{}, value: `-1`, wantRuleID: jsonOutOfRangeRuleID},
+		{name: "int64 quoted", msg: &wrapperspb.Int64Value{}, value: `"9223372036854775807"`},
+		{name: "int64 overflow", msg: &wrapperspb.Int64Value{}, value: `"9223372036854775808"`, wantRuleID: jsonOutOfRangeRuleID},
+		{name: "uint64 max", msg: &wrapperspb.UInt64Value{}, value: `18446744073709551615`},
+		{name: "float overflow", msg: &wrapperspb.FloatValue{}, value: `1e39`, wantRuleID: jsonOutOfRangeRuleID},
+		{name: "double NaN", msg: &wrapperspb.DoubleValue{}, value: `"NaN"`},
+		{name: "double word", msg: &wrapperspb.DoubleValue{}, value: `"cheap"`, wantRuleID: jsonTypeMismatchRuleID},
+		{name: "bool string", msg: &wrapperspb.BoolValue{}, value: `"true"`, wantRuleID: jsonTypeMismatchRuleID},
+		{name: "string number", msg: &wrapperspb.StringValue{}, value: `7`, wantRuleID: jsonTypeMismatchRuleID},
+		{name: "bytes base64", msg: &wrapperspb.BytesValue{}, value: `"aGVsbG8"`},
+		{name: "bytes invalid", msg: &wrapperspb.BytesValue{}, value: `"not base64!"`, wantRuleID: jsonInvalidFormatRuleID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := decodeJSONPayload([]byte(tt.value))
+			if !ok {
+				t.Fatalf("Invalid test value %s", tt.value)
+			}
+
+			// Wrapper types map to their bare value in JSON, so protojson is the reference
+			protojsonErr := protojson.Unmarshal([]byte(tt.value), tt.msg)
+			if (protojsonErr != nil) != (tt.wantRuleID != "") {
+				t.Fatalf("Test case disagrees with protojson: %v", protojsonErr)
+			}
+
+			fd := tt.msg.ProtoReflect().Descriptor().Fields().ByName("value")
+			problem := checkJSONScalar(fd, value)
+			switch {
+			case tt.wantRuleID == "" && problem != nil:
+				t.Errorf("Expected no problem, got %+v", problem)
+			case tt.wantRuleID != "" && (problem == nil || problem.ruleID != tt.wantRuleID):
+				t.Errorf("Expected rule %s, got %+v", tt.wantRuleID, problem)
+			}
+		})
+	}
+}
+
+func TestFindInvalidJSONValuesPointers(t *testing.T) {
+	md := (&wrapperspb.Int32Value{}).ProtoReflect().Descriptor()
+
+	// A value at the document root is located at the root pointer ""
+	errors := findInvalidJSONValues(md, []byte(`"abc"`), nil, fmt.Errorf("proto: invalid value"))
+	if len(errors) != 1 || errors[0].Pointer == nil || *errors[0].Pointer != "" {
+		t.Errorf("Expected one error at the root pointer, got %+v", errors)
+	}
+
+	// A failure the checker cannot attribute to a value has no location at all
+	errors = findInvalidJSONValues(md, []byte(`42`), nil, fmt.Errorf("proto: unresolvable type"))
+	if len(errors) != 1 || errors[0].Pointer != nil || errors[0].RuleID != jsonInvalidValueRuleID {
+		t.Errorf("Expected one error without a pointer, got %+v", errors)
+	}
+}
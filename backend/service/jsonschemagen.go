@@ -0,0 +1,523 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// jsonSchemaDialect is the JSON Schema dialect of generated documents
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Patterns of the string encodings protojson accepts for numbers
+const (
+	signedIntegerPattern   = "^-?[0-9]+$"
+	unsignedIntegerPattern = "^[0-9]+$"
+	floatPattern           = `^-?[0-9]+(\.[0-9]+)?([eE][+-]?[0-9]+)?$`
+	base64Pattern          = "^[A-Za-z0-9+/]*={0,2}$"
+)
+
+// stringFormats maps the well-known string rules of buf.validate to JSON Schema formats
+var stringFormats = map[string]func(*validate.StringRules) bool{
+	"email":         (*validate.StringRules).GetEmail,
+	"hostname":      (*validate.StringRules).GetHostname,
+	"ipv4":          (*validate.StringRules).GetIpv4,
+	"ipv6":          (*validate.StringRules).GetIpv6,
+	"uri":           (*validate.StringRules).GetUri,
+	"uri-reference": (*validate.StringRules).GetUriRef,
+	"uuid":          (*validate.StringRules).GetUuid,
+}
+
+// generateJSONSchema generates the variant of the JSON Schema of md from its descriptor,
+// in the layout protoschema-jsonschema generates: unbundled variants "$ref" the files of
+// other messages, bundled variants hold them in "$defs" (see bundleSchemaRefs)
+// buf.validate rules are mapped to keywords the way the plugin maps them, so a generated
+// document equals the plugin's file for the same definitions, except that descriptions
+// are only present if the descriptors carry source code info
+func generateJSONSchema(md protoreflect.MessageDescriptor, variant SchemaVariant) ([]byte, error) {
+	unbundled := variant
+	unbundled.Unbundled = true
+	gen := &jsonSchemaGenerator{variant: unbundled, refs: make(map[string]protoreflect.MessageDescriptor)}
+
+	root, err := json.MarshalIndent(gen.messageSchema(md), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema of %s: %w", md.FullName(), err)
+	}
+	if variant.Unbundled {
+		return root, nil
+	}
+
+	// Referenced messages are known once the documents referring to them are generated
+	return bundleSchemaRefs(string(md.FullName()), root, unbundled, func(messageName string) ([]byte, error) {
+		ref, ok := gen.refs[messageName]
+		if !ok {
+			return nil, fmt.Errorf("message %s %w", messageName, ErrNotFound)
+		}
+		return json.MarshalIndent(gen.messageSchema(ref), "", "  ")
+	})
+}
+
+// jsonSchemaGenerator generates the unbundled documents of one variant
+type jsonSchemaGenerator struct {
+	variant SchemaVariant
+	refs    map[string]protoreflect.MessageDescriptor // Messages referenced so far, by full name
+}
+
+// messageSchema returns the document of md
+func (g *jsonSchemaGenerator) messageSchema(md protoreflect.MessageDescriptor) map[string]interface{} {
+	schema := wellKnownTypeSchema(md, g.variant)
+	if schema == nil {
+		schema = g.objectSchema(md)
+	}
+	schema["$id"] = g.variant.FileName(string(md.FullName()))
+	schema["$schema"] = jsonSchemaDialect
+	schema["title"] = schemaTitle(string(md.Name()))
+	return schema
+}
+
+// objectSchema returns the schema of a message encoded as a JSON object
+// Unknown properties are rejected; in the lenient variants the name protojson also accepts
+// (the JSON name, or the proto name for JSON-named variants) is allowed as a pattern property
+func (g *jsonSchemaGenerator) objectSchema(md protoreflect.MessageDescriptor) map[string]interface{} {
+	properties := make(map[string]interface{})
+	patternProperties := make(map[string]interface{})
+	var required []string
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name, alias := string(fd.Name()), fd.JSONName()
+		if g.variant.JSONNames {
+			name, alias = alias, name
+		}
+
+		rules := fieldRules(fd)
+		fieldSchema := g.fieldSchema(fd, rules)
+		properties[name] = fieldSchema
+		if !g.variant.Strict && alias != name {
+			patternProperties["^("+alias+")$"] = fieldSchema
+		}
+
+		// Strict variants require the fields protojson always emits
+		if rules.GetRequired() || (g.variant.Strict && !fd.HasPresence() && !fd.IsList() && !fd.IsMap()) {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(patternProperties) > 0 {
+		schema["patternProperties"] = patternProperties
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	setDescription(schema, md)
+	return schema
+}
+
+// fieldSchema returns the schema of the value of fd, with its rules applied
+func (g *jsonSchemaGenerator) fieldSchema(fd protoreflect.FieldDescriptor, rules *validate.FieldRules) map[string]interface{} {
+	var schema map[string]interface{}
+	switch {
+	case fd.IsMap():
+		schema = map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": g.valueSchema(fd.MapValue(), rules.GetMap().GetValues()),
+		}
+	case fd.IsList():
+		schema = map[string]interface{}{
+			"type":  "array",
+			"items": g.valueSchema(fd, rules.GetRepeated().GetItems()),
+		}
+	default:
+		schema = g.valueSchema(fd, rules)
+		if rules.GetRequired() {
+			requireValue(fd, schema)
+		} else if !g.variant.Strict && !fd.HasPresence() {
+			setDefault(fd, schema)
+		}
+	}
+	setDescription(schema, fd)
+	return schema
+}
+
+// valueSchema returns the schema of a single value of fd (an element for lists and maps)
+func (g *jsonSchemaGenerator) valueSchema(fd protoreflect.FieldDescriptor, rules *validate.FieldRules) map[string]interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		md := fd.Message()
+		g.refs[string(md.FullName())] = md
+		return map[string]interface{}{"$ref": g.variant.FileName(string(md.FullName()))}
+	case protoreflect.EnumKind:
+		return g.enumSchema(fd.Enum(), rules)
+	case protoreflect.StringKind:
+		return stringSchema(rules.GetString())
+	case protoreflect.BytesKind:
+		return map[string]interface{}{"type": "string", "pattern": base64Pattern}
+	case protoreflect.BoolKind:
+		schema := map[string]interface{}{"type": "boolean"}
+		if rules.GetBool().HasConst() {
+			schema["const"] = rules.GetBool().GetConst()
+		}
+		return schema
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return g.floatSchema(numericRulesOf(rules))
+	default:
+		return g.integerSchema(fd.Kind(), numericRulesOf(rules))
+	}
+}
+
+// enumSchema returns the schema of an enum value: its name, or its number in lenient variants
+// defined_only, in, not_in and required narrow the allowed values
+func (g *jsonSchemaGenerator) enumSchema(ed protoreflect.EnumDescriptor, rules *validate.FieldRules) map[string]interface{} {
+	enumRules := rules.GetEnum()
+	in := make(map[int32]bool)
+	for _, number := range enumRules.GetIn() {
+		in[number] = true
+	}
+	notIn := make(map[int32]bool)
+	for _, number := range enumRules.GetNotIn() {
+		notIn[number] = true
+	}
+
+	var names []string
+	minimum, maximum := int32(math.MaxInt32), int32(math.MinInt32)
+	values := ed.Values()
+	for i := 0; i < values.Len(); i++ {
+		number := int32(values.Get(i).Number())
+		if notIn[number] || (len(in) > 0 && !in[number]) || (rules.GetRequired() && number == 0) {
+			continue
+		}
+		if enumRules.HasConst() && number != enumRules.GetConst() {
+			continue
+		}
+		names = append(names, string(values.Get(i).Name()))
+		minimum, maximum = min(minimum, number), max(maximum, number)
+	}
+
+	title := schemaTitle(string(ed.Name()))
+	nameSchema := map[string]interface{}{"type": "string", "enum": names}
+	if g.variant.Strict {
+		nameSchema["title"] = title
+		return nameSchema
+	}
+
+	// Undefined numbers are only rejected with defined_only, or if the values are listed
+	numberSchema := map[string]interface{}{"type": "integer", "minimum": int64(math.MinInt32), "maximum": int64(math.MaxInt32)}
+	if (enumRules.GetDefinedOnly() || len(in) > 0 || enumRules.HasConst()) && len(names) > 0 {
+		numberSchema["minimum"], numberSchema["maximum"] = int64(minimum), int64(maximum)
+	}
+	return map[string]interface{}{
+		"anyOf": []interface{}{nameSchema, numberSchema},
+		"title": title,
+	}
+}
+
+// stringSchema returns the schema of a string value with its rules applied
+func stringSchema(rules *validate.StringRules) map[string]interface{} {
+	schema := map[string]interface{}{"type": "string"}
+	if rules == nil {
+		return schema
+	}
+	if rules.HasConst() {
+		schema["const"] = rules.GetConst()
+	}
+	if rules.HasLen() {
+		schema["minLength"], schema["maxLength"] = rules.GetLen(), rules.GetLen()
+	}
+	if rules.HasMinLen() {
+		schema["minLength"] = rules.GetMinLen()
+	}
+	if rules.HasMaxLen() {
+		schema["maxLength"] = rules.GetMaxLen()
+	}
+	if rules.HasPattern() {
+		schema["pattern"] = rules.GetPattern()
+	}
+	if len(rules.GetIn()) > 0 {
+		schema["enum"] = rules.GetIn()
+	}
+	if len(rules.GetNotIn()) > 0 {
+		schema["not"] = map[string]interface{}{"enum": rules.GetNotIn()}
+	}
+	for format, isSet := range stringFormats {
+		if isSet(rules) {
+			schema["format"] = format
+		}
+	}
+	return schema
+}
+
+// integerSchema returns the schema of an integer value of kind with its rules applied
+// 32-bit kinds are bounded by their range unless the rules bound them; lenient variants
+// also accept the number as a string, as protojson does
+func (g *jsonSchemaGenerator) integerSchema(kind protoreflect.Kind, rules numericRules) map[string]interface{} {
+	schema := map[string]interface{}{"type": "integer"}
+	pattern := signedIntegerPattern
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		schema["minimum"], schema["exclusiveMaximum"] = int64(math.MinInt32), int64(math.MaxInt32)+1
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		schema["minimum"], schema["exclusiveMaximum"] = int64(0), int64(math.MaxUint32)+1
+		pattern = unsignedIntegerPattern
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		schema["minimum"] = int64(0)
+		pattern = unsignedIntegerPattern
+	}
+	rules.apply(schema)
+
+	if g.variant.Strict {
+		return schema
+	}
+	return map[string]interface{}{
+		"anyOf": []interface{}{schema, map[string]interface{}{"type": "string", "pattern": pattern}},
+	}
+}
+
+// floatSchema returns the schema of a float or double value with its rules applied
+// The infinities protojson encodes as strings are allowed on the sides the rules leave
+// unbounded, and NaN if both are
+func (g *jsonSchemaGenerator) floatSchema(rules numericRules) map[string]interface{} {
+	schema := map[string]interface{}{"type": "number"}
+	rules.apply(schema)
+
+	var special []string
+	_, hasMaximum := schema["maximum"]
+	_, hasExclusiveMaximum := schema["exclusiveMaximum"]
+	_, hasMinimum := schema["minimum"]
+	_, hasExclusiveMinimum := schema["exclusiveMinimum"]
+	upper, lower := hasMaximum || hasExclusiveMaximum, hasMinimum || hasExclusiveMinimum
+	if !upper {
+		special = append(special, "Infinity")
+	}
+	if !lower {
+		special = append(special, "-Infinity")
+	}
+	if !upper && !lower {
+		special = append(special, "NaN")
+	}
+	if rules.finite || rules.constant != nil || len(rules.in) > 0 {
+		special = nil
+	}
+
+	anyOf := []interface{}{schema}
+	if len(special) > 0 {
+		anyOf = append(anyOf, map[string]interface{}{"type": "string", "enum": special})
+	}
+	if !g.variant.Strict {
+		if len(special) > 0 {
+			anyOf = append(anyOf, map[string]interface{}{"type": "string"})
+		} else {
+			anyOf = append(anyOf, map[string]interface{}{"type": "string", "pattern": floatPattern})
+		}
+	}
+	if len(anyOf) == 1 {
+		return schema
+	}
+	return map[string]interface{}{"anyOf": anyOf}
+}
+
+// requireValue makes the schema of a required singular field reject the zero value where
+// the schema allows it
+func requireValue(fd protoreflect.FieldDescriptor, schema map[string]interface{}) {
+	if fd.Kind() != protoreflect.StringKind && fd.Kind() != protoreflect.BytesKind {
+		return
+	}
+	if _, ok := schema["minLength"]; !ok {
+		schema["minLength"] = 1
+	}
+}
+
+// setDefault sets the default of a field without presence: the zero value protojson omits
+func setDefault(fd protoreflect.FieldDescriptor, schema map[string]interface{}) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return
+	case protoreflect.EnumKind:
+		if zero := fd.Enum().Values().ByNumber(0); zero != nil {
+			schema["default"] = string(zero.Name())
+		}
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		schema["default"] = ""
+	case protoreflect.BoolKind:
+		schema["default"] = false
+	default:
+		schema["default"] = 0
+	}
+}
+
+// setDescription sets the description of schema to the leading comments of desc, if the
+// descriptors carry source code info
+func setDescription(schema map[string]interface{}, desc protoreflect.Descriptor) {
+	comments := strings.TrimSpace(desc.ParentFile().SourceLocations().ByDescriptor(desc).LeadingComments)
+	if comments != "" {
+		schema["description"] = comments
+	}
+}
+
+// schemaTitle splits a CamelCase name into words, e.g. "Complex Order" for "ComplexOrder"
+func schemaTitle(name string) string {
+	var title strings.Builder
+	var prev rune
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) {
+			title.WriteRune(' ')
+		}
+		title.WriteRune(r)
+		prev = r
+	}
+	return title.String()
+}
+
+// wellKnownTypeSchema returns the schema of the well-known types protojson encodes specially,
+// or nil for other messages
+func wellKnownTypeSchema(md protoreflect.MessageDescriptor, variant SchemaVariant) map[string]interface{} {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case "google.protobuf.Duration":
+		return map[string]interface{}{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]+)?s$`}
+	case "google.protobuf.FieldMask":
+		return map[string]interface{}{"type": "string"}
+	case "google.protobuf.Struct":
+		return map[string]interface{}{"type": "object"}
+	case "google.protobuf.ListValue":
+		return map[string]interface{}{"type": "array"}
+	case "google.protobuf.Value":
+		return map[string]interface{}{}
+	case "google.protobuf.Empty":
+		return map[string]interface{}{"type": "object", "additionalProperties": false}
+	case "google.protobuf.Any":
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"@type": map[string]interface{}{"type": "string"}},
+			"required":   []string{"@type"},
+		}
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue", "google.protobuf.Int64Value",
+		"google.protobuf.UInt64Value", "google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		// Wrappers are encoded as their value
+		gen := &jsonSchemaGenerator{variant: variant}
+		return gen.valueSchema(md.Fields().ByName("value"), nil)
+	default:
+		return nil
+	}
+}
+
+// numericRules are the rules of a numeric field, whatever its kind
+type numericRules struct {
+	gt, gte, lt, lte interface{} // Bounds, nil if not set
+	constant         interface{}
+	in, notIn        []interface{}
+	finite           bool
+}
+
+// apply sets the keywords of the rules on schema, replacing the bounds of the kind
+func (r numericRules) apply(schema map[string]interface{}) {
+	if r.gte != nil {
+		schema["minimum"] = r.gte
+	}
+	if r.gt != nil {
+		delete(schema, "minimum")
+		schema["exclusiveMinimum"] = r.gt
+	}
+	if r.lte != nil {
+		delete(schema, "exclusiveMaximum")
+		schema["maximum"] = r.lte
+	}
+	if r.lt != nil {
+		schema["exclusiveMaximum"] = r.lt
+	}
+	if r.constant != nil {
+		schema["const"] = r.constant
+	}
+	if len(r.in) > 0 {
+		schema["enum"] = r.in
+	}
+	if len(r.notIn) > 0 {
+		schema["not"] = map[string]interface{}{"enum": r.notIn}
+	}
+}
+
+// numericRulesOf extracts the numeric rules of any kind from rules
+// The rules are read reflectively: every numeric rules message has the same field names
+func numericRulesOf(rules *validate.FieldRules) numericRules {
+	var result numericRules
+	if rules == nil {
+		return result
+	}
+	typed := rules.ProtoReflect().WhichOneof(rules.ProtoReflect().Descriptor().Oneofs().ByName("type"))
+	if typed == nil || typed.Message() == nil {
+		return result
+	}
+	msg := rules.ProtoReflect().Get(typed).Message()
+	fields := msg.Descriptor().Fields()
+	get := func(name protoreflect.Name) interface{} {
+		fd := fields.ByName(name)
+		if fd == nil || !msg.Has(fd) {
+			return nil
+		}
+		return msg.Get(fd).Interface()
+	}
+	list := func(name protoreflect.Name) []interface{} {
+		fd := fields.ByName(name)
+		if fd == nil {
+			return nil
+		}
+		var values []interface{}
+		l := msg.Get(fd).List()
+		for i := 0; i < l.Len(); i++ {
+			values = append(values, l.Get(i).Interface())
+		}
+		return values
+	}
+
+	result.gt, result.gte, result.lt, result.lte = get("gt"), get("gte"), get("lt"), get("lte")
+	result.constant = get("const")
+	result.in, result.notIn = list("in"), list("not_in")
+	if finite, ok := get("finite").(bool); ok {
+		result.finite = finite
+	}
+	return result
+}
+
+// fieldRules returns the buf.validate rules of fd, or nil if it has none
+// Options of descriptors built at runtime (e.g. fetched from BSR) may hold the rules as
+// unknown fields or as a dynamic message, they are decoded into the generated type
+func fieldRules(fd protoreflect.FieldDescriptor) *validate.FieldRules {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil {
+		return nil
+	}
+	if proto.HasExtension(opts, validate.E_Field) {
+		if rules, ok := proto.GetExtension(opts, validate.E_Field).(*validate.FieldRules); ok {
+			return rules
+		}
+	}
+	if !proto.HasExtension(opts, validate.E_Field) && len(opts.ProtoReflect().GetUnknown()) == 0 {
+		return nil
+	}
+
+	data, err := proto.Marshal(opts)
+	if err != nil {
+		return nil
+	}
+	decoded := &descriptorpb.FieldOptions{}
+	if err := (proto.UnmarshalOptions{Resolver: protoregistry.GlobalTypes}).Unmarshal(data, decoded); err != nil {
+		return nil
+	}
+	rules, _ := proto.GetExtension(decoded, validate.E_Field).(*validate.FieldRules)
+	return rules
+}
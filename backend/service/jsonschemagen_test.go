@@ -0,0 +1,124 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	_ "validation-service/backend/proto"
+)
+
+// withoutDescriptions removes the "description" keywords of a decoded schema document
+// The compiled-in descriptors carry no comments, so generated documents have none
+func withoutDescriptions(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if _, ok := v["description"].(string); ok {
+			delete(v, "description")
+		}
+		for _, child := range v {
+			withoutDescriptions(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			withoutDescriptions(child)
+		}
+	}
+}
+
+func TestGenerateJSONSchemaMatchesGeneratedFiles(t *testing.T) {
+	dir := filepath.Join("..", "gen", "jsonschema")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", dir, err)
+	}
+
+	var variants []SchemaVariant
+	for _, jsonNames := range []bool{false, true} {
+		for _, strict := range []bool{false, true} {
+			for _, unbundled := range []bool{false, true} {
+				variants = append(variants, SchemaVariant{JSONNames: jsonNames, Strict: strict, Unbundled: unbundled})
+			}
+		}
+	}
+
+	compared := 0
+	for _, entry := range entries {
+		for _, variant := range variants {
+			messageName, ok := variant.messageName(entry.Name())
+			if !ok {
+				continue
+			}
+			t.Run(entry.Name(), func(t *testing.T) {
+				desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(messageName))
+				if err != nil {
+					t.Fatalf("Failed to find %s: %v", messageName, err)
+				}
+				generated, err := generateJSONSchema(desc.(protoreflect.MessageDescriptor), variant)
+				if err != nil {
+					t.Fatalf("generateJSONSchema failed: %v", err)
+				}
+
+				file, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+				if err != nil {
+					t.Fatalf("Failed to read %s: %v", entry.Name(), err)
+				}
+				var want, got interface{}
+				if err := json.Unmarshal(file, &want); err != nil {
+					t.Fatalf("Failed to parse %s: %v", entry.Name(), err)
+				}
+				if err := json.Unmarshal(generated, &got); err != nil {
+					t.Fatalf("Failed to parse generated schema: %v", err)
+				}
+				withoutDescriptions(want)
+
+				if !reflect.DeepEqual(got, want) {
+					wantJSON, _ := json.MarshalIndent(want, "", "  ")
+					t.Errorf("Generated schema differs from %s\ngot:\n%s\nwant:\n%s", entry.Name(), generated, wantJSON)
+				}
+			})
+			compared++
+		}
+	}
+	if compared == 0 {
+		t.Fatalf("No schema files compared in %s", dir)
+	}
+}
+
+func TestGeneratedSchemaSource(t *testing.T) {
+	source := NewGeneratedSchemaSource(NewRegistryDescriptorSource(isolatedTaskFiles(t)))
+
+	schema, err := source.GetSchema("proto.Task", "main", SchemaVariant{Unbundled: true})
+	if err != nil {
+		t.Fatalf("GetSchema failed: %v", err)
+	}
+	if schema.Source != "generated" || schema.Commit != LocalVersion {
+		t.Errorf("Expected generated schema of commit %s, got source=%s, commit=%s", LocalVersion, schema.Source, schema.Commit)
+	}
+	doc, err := decodeSchemaDocument(schema.Data)
+	if err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
+	}
+	if _, ok := doc["properties"].(map[string]interface{})["extra"]; !ok || doc["$id"] != "proto.Task.schema.json" {
+		t.Errorf("Expected the schema of the isolated proto.Task, got %s", schema.Data)
+	}
+
+	if _, err := source.GetSchema("proto.Missing", "main", SchemaVariant{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown message, got %v", err)
+	}
+	if _, err := source.GetSchema("proto.Task", testCommitID, SchemaVariant{}); !errors.Is(err, ErrVersionUnavailable) {
+		t.Errorf("Expected ErrVersionUnavailable for a commit, got %v", err)
+	}
+
+	// Enums have no schema of their own
+	enums := NewGeneratedSchemaSource(NewRegistryDescriptorSource(nil))
+	if _, err := enums.GetSchema("proto.TaskStatus", "main", SchemaVariant{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an enum, got %v", err)
+	}
+}
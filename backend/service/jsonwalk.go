@@ -0,0 +1,281 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// wellKnownTypePrefix is the package of the protobuf well-known types, which have
+// special JSON mappings (Timestamp as a string, Struct as an arbitrary object, etc.)
+const wellKnownTypePrefix = "google.protobuf."
+
+// jsonPayloadChecker walks a decoded JSON payload alongside a message descriptor and
+// collects problems that protojson would otherwise silently drop or report without a path
+type jsonPayloadChecker struct {
+	unknownFields  bool         // Report keys that are not part of the schema
+	undefinedEnums bool         // Report enum names that are not defined, protojson drops them like unknown fields
+	invalidValues  bool         // Report values protojson cannot decode (wrong type, out of range, etc.)
+	resolver       typeResolver // Resolves Any types when decoding well-known type values
+	errors         []ValidationError
+}
+
+// jsonLocation is the position of a value in the payload, tracked with proto field names,
+// JSON field names and as a JSON Pointer so errors line up with protovalidate violations
+type jsonLocation struct {
+	field     string
+	jsonField string
+	pointer   string
+}
+
+// child returns the location of field fd (found under the JSON object key) inside l
+func (l jsonLocation) child(fd protoreflect.FieldDescriptor, key string) jsonLocation {
+	return jsonLocation{
+		field:     joinJSONFieldPath(l.field, string(fd.Name())),
+		jsonField: joinJSONFieldPath(l.jsonField, fd.JSONName()),
+		pointer:   l.pointer + "/" + escapeJSONPointerToken(key),
+	}
+}
+
+// index returns the location of a list element or map entry inside l
+// subscript is rendered as in violation field paths, token is the JSON Pointer reference token
+func (l jsonLocation) index(subscript, token string) jsonLocation {
+	return jsonLocation{
+		field:     l.field + "[" + subscript + "]",
+		jsonField: l.jsonField + "[" + subscript + "]",
+		pointer:   l.pointer + "/" + escapeJSONPointerToken(token),
+	}
+}
+
+// findUnknownJSONFields reports every JSON object key in payload that does not map to a
+// field of md (or of the nested message it belongs to), with its JSON path and pointer
+// Enum names that are not defined are reported too, as protojson drops them like unknown fields
+// Malformed JSON is ignored here and left for protojson to report
+func findUnknownJSONFields(md protoreflect.MessageDescriptor, payload []byte) []ValidationError {
+	value, ok := decodeJSONPayload(payload)
+	if !ok {
+		return nil
+	}
+
+	checker := &jsonPayloadChecker{unknownFields: true, undefinedEnums: true}
+	checker.checkMessage(md, value, jsonLocation{})
+	return checker.errors
+}
+
+// findUndefinedJSONEnums reports every enum name in payload that is not defined by its enum,
+// which protojson drops without an error and would otherwise only surface as a missing value
+// Used outside strict mode, where findUnknownJSONFields already reports them
+// Malformed JSON is ignored here and left for protojson to report
+func findUndefinedJSONEnums(md protoreflect.MessageDescriptor, payload []byte) []ValidationError {
+	value, ok := decodeJSONPayload(payload)
+	if !ok {
+		return nil
+	}
+
+	checker := &jsonPayloadChecker{undefinedEnums: true}
+	checker.checkMessage(md, value, jsonLocation{})
+	return checker.errors
+}
+
+// findInvalidJSONValues explains a protojson decode failure (decodeErr) as structured errors,
+// one for every value in payload that cannot be decoded into its field
+// resolver is the one the payload was decoded with
+// Returns nil if payload is not well-formed JSON, which has no field to point at
+func findInvalidJSONValues(md protoreflect.MessageDescriptor, payload []byte, resolver typeResolver, decodeErr error) []ValidationError {
+	value, ok := decodeJSONPayload(payload)
+	if !ok {
+		return nil
+	}
+
+	checker := &jsonPayloadChecker{invalidValues: true, resolver: resolver}
+	checker.checkMessage(md, value, jsonLocation{})
+	if len(checker.errors) > 0 {
+		return checker.errors
+	}
+
+	// protojson rejected something the checker does not model (e.g. an unresolvable Any type),
+	// report it against the whole payload so it still reaches the caller as a violation
+	technical := decodeErr.Error()
+	return []ValidationError{{
+		Friendly:  "payload could not be decoded as " + string(md.FullName()) + ": " + strings.TrimPrefix(technical, "proto: "),
+		Technical: technical,
+		RuleID:    jsonInvalidValueRuleID,
+	}}
+}
+
+// decodeJSONPayload decodes payload keeping numbers as json.Number so no precision is lost
+func decodeJSONPayload(payload []byte) (interface{}, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// checkMessage checks a JSON value that should hold the message md
+func (c *jsonPayloadChecker) checkMessage(md protoreflect.MessageDescriptor, value interface{}, loc jsonLocation) {
+	if strings.HasPrefix(string(md.FullName()), wellKnownTypePrefix) {
+		if c.invalidValues {
+			c.checkWellKnownType(md, value, loc)
+		}
+		return
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		if c.invalidValues {
+			c.addTypeMismatch(loc, "an object", value)
+		}
+		return
+	}
+
+	oneofs := make(map[protoreflect.FullName]string)
+	for _, key := range sortedKeys(object) {
+		fd := findJSONField(md, key)
+		if fd == nil {
+			if strings.HasPrefix(key, "[") && strings.HasSuffix(key, "]") {
+				// Extension fields are resolved by protojson itself
+				continue
+			}
+			if c.unknownFields {
+				fieldPath := joinJSONFieldPath(loc.jsonField, key)
+				c.errors = append(c.errors, ValidationError{
+					Friendly:  fmt.Sprintf("field '%s': is not defined in %s", fieldPath, md.FullName()),
+					Technical: fmt.Sprintf("%s: unknown field %q in %s", fieldPath, key, md.FullName()),
+					Field:     joinJSONFieldPath(loc.field, key),
+					JSONField: fieldPath,
+					Pointer:   pointerTo(loc.pointer + "/" + escapeJSONPointerToken(key)),
+					RuleID:    unknownFieldRuleID,
+					Value:     object[key],
+				})
+			}
+			continue
+		}
+
+		fieldLoc := loc.child(fd, key)
+		if object[key] == nil && !acceptsJSONNull(fd) {
+			// null means "not set" for every field except google.protobuf.Value and NullValue
+			continue
+		}
+
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() && c.invalidValues {
+			if previous, ok := oneofs[oneof.FullName()]; ok {
+				c.errors = append(c.errors, ValidationError{
+					Friendly:  fmt.Sprintf("field '%s': cannot be set together with '%s', only one field of %s may be set", fieldLoc.jsonField, previous, oneof.Name()),
+					Technical: fmt.Sprintf("%s: oneof %s is already set by %s", fieldLoc.jsonField, oneof.FullName(), previous),
+					Field:     fieldLoc.field,
+					JSONField: fieldLoc.jsonField,
+					Pointer:   pointerTo(fieldLoc.pointer),
+					RuleID:    jsonOneofConflictRuleID,
+				})
+				continue
+			}
+			oneofs[oneof.FullName()] = fieldLoc.jsonField
+		}
+
+		c.checkField(fd, object[key], fieldLoc)
+	}
+}
+
+// checkField checks the JSON value of a single field, descending into lists, maps and nested messages
+func (c *jsonPayloadChecker) checkField(fd protoreflect.FieldDescriptor, value interface{}, loc jsonLocation) {
+	switch {
+	case fd.IsMap():
+		entries, ok := value.(map[string]interface{})
+		if !ok {
+			if c.invalidValues {
+				c.addTypeMismatch(loc, "an object", value)
+			}
+			return
+		}
+		for _, key := range sortedKeys(entries) {
+			entryLoc := loc.index(strconv.Quote(key), key)
+			if c.invalidValues {
+				if problem := checkJSONMapKey(fd.MapKey(), key); problem != nil {
+					problem.report(c, entryLoc, key)
+					continue
+				}
+			}
+			c.checkValue(fd.MapValue(), entries[key], entryLoc)
+		}
+	case fd.IsList():
+		elements, ok := value.([]interface{})
+		if !ok {
+			if c.invalidValues {
+				c.addTypeMismatch(loc, "an array", value)
+			}
+			return
+		}
+		for i, element := range elements {
+			c.checkValue(fd, element, loc.index(strconv.Itoa(i), strconv.Itoa(i)))
+		}
+	default:
+		c.checkValue(fd, value, loc)
+	}
+}
+
+// checkValue checks a single (non-list, non-map) value of field fd
+func (c *jsonPayloadChecker) checkValue(fd protoreflect.FieldDescriptor, value interface{}, loc jsonLocation) {
+	if fd.Message() != nil {
+		c.checkMessage(fd.Message(), value, loc)
+		return
+	}
+
+	if fd.Kind() == protoreflect.EnumKind && c.undefinedEnums {
+		if name, ok := value.(string); ok && fd.Enum().Values().ByName(protoreflect.Name(name)) == nil {
+			c.errors = append(c.errors, ValidationError{
+				Friendly:  fmt.Sprintf("field '%s': '%s' is not a valid %s value", loc.jsonField, name, fd.Enum().Name()),
+				Technical: fmt.Sprintf("%s: unknown enum value %q for %s", loc.jsonField, name, fd.Enum().FullName()),
+				Field:     loc.field,
+				JSONField: loc.jsonField,
+				Pointer:   pointerTo(loc.pointer),
+				RuleID:    jsonInvalidEnumRuleID,
+				Value:     name,
+			})
+		}
+		return
+	}
+
+	if c.invalidValues {
+		if problem := checkJSONScalar(fd, value); problem != nil {
+			problem.report(c, loc, value)
+		}
+	}
+}
+
+// findJSONField finds a field by its JSON name or its original proto name, as protojson does
+func findJSONField(md protoreflect.MessageDescriptor, key string) protoreflect.FieldDescriptor {
+	if fd := md.Fields().ByJSONName(key); fd != nil {
+		return fd
+	}
+	if fd := md.Fields().ByTextName(key); fd != nil {
+		return fd
+	}
+	return nil
+}
+
+// joinJSONFieldPath appends a field name to a JSON field path
+func joinJSONFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// sortedKeys returns the keys of a JSON object in sorted order so errors are reported deterministically
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
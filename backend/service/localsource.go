@@ -0,0 +1,141 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"validation-service/backend/logger"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// LocalVersion names the single version served by local sources, it is reported as their
+// resolved commit and may be requested explicitly
+const LocalVersion = "local"
+
+// localVersions are the versions local sources accept: their own name and the default label,
+// which the local version stands in for
+var localVersions = map[string]bool{"": true, "main": true, LocalVersion: true}
+
+// checkLocalVersion returns an error wrapping ErrVersionUnavailable if a local source
+// cannot serve version
+func checkLocalVersion(sourceName, version string) error {
+	if localVersions[version] {
+		return nil
+	}
+	return fmt.Errorf("%w: commit %s cannot be served by the %s source, it only has version %q", ErrVersionUnavailable, version, sourceName, LocalVersion)
+}
+
+// RegistryDescriptorSource resolves messages from a protobuf registry, by default the
+// types compiled into the binary (protoregistry.GlobalFiles)
+// The registry has a single version, so a request for a specific commit or label other
+// than the default fails with ErrVersionUnavailable instead of being silently ignored
+type RegistryDescriptorSource struct {
+	files   *protoregistry.Files
+	digests sync.Map // Message full name to descriptorDigest, the registry never changes
+}
+
+// NewRegistryDescriptorSource creates a source over files, nil means protoregistry.GlobalFiles
+func NewRegistryDescriptorSource(files *protoregistry.Files) *RegistryDescriptorSource {
+	if files == nil {
+		files = protoregistry.GlobalFiles
+	}
+	return &RegistryDescriptorSource{files: files}
+}
+
+// Name implements DescriptorSource
+func (s *RegistryDescriptorSource) Name() string {
+	return "local"
+}
+
+// FindMessage implements DescriptorSource
+func (s *RegistryDescriptorSource) FindMessage(schemaName, version string) (*ResolvedMessage, error) {
+	if err := checkLocalVersion(s.Name(), version); err != nil {
+		logger.Debug("Local registry cannot serve version %s of %s", version, schemaName)
+		return nil, err
+	}
+
+	md, err := findMessageInFiles(s.files, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("Found message descriptor in local registry: %s", schemaName)
+	return &ResolvedMessage{Descriptor: md, Files: s.files, Source: s.Name(), Commit: LocalVersion, Digest: s.digest(md)}, nil
+}
+
+// RangeFiles implements FileLister, the registry is the default version
+func (s *RegistryDescriptorSource) RangeFiles(f func(version string, files *protoregistry.Files) bool) {
+	f("", s.files)
+}
+
+// digest returns the memoized descriptorDigest of md
+func (s *RegistryDescriptorSource) digest(md protoreflect.MessageDescriptor) string {
+	if digest, ok := s.digests.Load(md.FullName()); ok {
+		return digest.(string)
+	}
+	digest := descriptorDigest(md)
+	s.digests.Store(md.FullName(), digest)
+	return digest
+}
+
+// LocalSchemaSource reads JSON Schema files generated by protoschema-jsonschema,
+// named {FULL_NAME}.{VARIANT}.json, e.g. proto.Task.schema.bundle.json
+// The files are a single version, named LocalVersion
+type LocalSchemaSource struct {
+	dir string
+}
+
+// NewLocalSchemaSource creates a source over the JSON Schema files in dir, e.g. gen/jsonschema
+func NewLocalSchemaSource(dir string) *LocalSchemaSource {
+	return &LocalSchemaSource{dir: dir}
+}
+
+// Name implements SchemaSource
+func (s *LocalSchemaSource) Name() string {
+	return "local"
+}
+
+// GetSchema implements SchemaSource
+func (s *LocalSchemaSource) GetSchema(messageName, version string, variant SchemaVariant) (*ResolvedSchema, error) {
+	if err := checkLocalVersion(s.Name(), version); err != nil {
+		logger.Debug("Local schema files cannot serve version %s of %s", version, messageName)
+		return nil, err
+	}
+
+	schemaPath := filepath.Join(s.dir, variant.FileName(messageName))
+	logger.Debug("Checking local schema path: %s", schemaPath)
+
+	data, err := os.ReadFile(schemaPath)
+	if errors.Is(err, os.ErrNotExist) {
+		logger.Debug("Local schema file not found: %s", schemaPath)
+		return nil, fmt.Errorf("schema for %s %w locally", messageName, ErrNotFound)
+	}
+	if err != nil {
+		logger.Error("Failed to read local schema file %s: %v", schemaPath, err)
+		return nil, fmt.Errorf("failed to read local schema: %w", err)
+	}
+
+	logger.Debug("Local schema file found and read successfully: %s", schemaPath)
+	return &ResolvedSchema{Data: data, Source: s.Name(), Commit: LocalVersion}, nil
+}
+
+// findMessageInFiles looks up a message by fully qualified name in files
+// Returns an error wrapping ErrNotFound if files has no such message
+func findMessageInFiles(files *protoregistry.Files, schemaName string) (protoreflect.MessageDescriptor, error) {
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(schemaName))
+	if errors.Is(err, protoregistry.NotFound) {
+		return nil, fmt.Errorf("message %s %w", schemaName, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	md, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%w: schema name %s does not refer to a message", ErrInvalidArgument, schemaName)
+	}
+	return md, nil
+}
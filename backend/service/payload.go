@@ -0,0 +1,155 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// unknownFieldRuleID is the rule id reported for fields that are not part of the message definition
+const unknownFieldRuleID = "unknown_field"
+
+// ValidateOptions controls how a payload is decoded before validation
+type ValidateOptions struct {
+	// Format is the payload encoding, defaults to JSON
+	Format PayloadFormat
+	// Strict reports unknown fields as violations instead of silently dropping them
+	// nil uses the deployment default configured on the ValidationService
+	Strict *bool
+}
+
+// PayloadFormat identifies how a payload to validate is encoded
+type PayloadFormat int
+
+const (
+	// PayloadFormatJSON is the protobuf JSON mapping (protojson)
+	PayloadFormatJSON PayloadFormat = iota
+	// PayloadFormatBinary is the protobuf wire format
+	PayloadFormatBinary
+	// PayloadFormatText is the protobuf text format (prototext), handy for hand-written fixtures
+	PayloadFormatText
+)
+
+// String returns the string representation of the payload format
+func (f PayloadFormat) String() string {
+	switch f {
+	case PayloadFormatJSON:
+		return "JSON"
+	case PayloadFormatBinary:
+		return "binary"
+	case PayloadFormatText:
+		return "text"
+	default:
+		return "unknown"
+	}
+}
+
+// typeResolver resolves the message types of Any values and extension fields while decoding
+type typeResolver interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+}
+
+// unmarshalPayload decodes payload into msg according to format
+// Any values and extensions are resolved with resolver only, never the global registry
+// In strict mode the text format rejects unknown fields; JSON and binary unknown fields
+// are reported separately by the caller so they can carry their path
+func unmarshalPayload(payload []byte, format PayloadFormat, strict bool, resolver typeResolver, msg proto.Message) error {
+	switch format {
+	case PayloadFormatJSON:
+		unmarshalOpts := protojson.UnmarshalOptions{
+			DiscardUnknown: true, // Ignore unknown fields
+			Resolver:       resolver,
+		}
+		return unmarshalOpts.Unmarshal(payload, msg)
+	case PayloadFormatBinary:
+		unmarshalOpts := proto.UnmarshalOptions{
+			Resolver: resolver,
+		}
+		return unmarshalOpts.Unmarshal(payload, msg)
+	case PayloadFormatText:
+		unmarshalOpts := prototext.UnmarshalOptions{
+			DiscardUnknown: !strict, // Ignore unknown fields unless strict
+			Resolver:       resolver,
+		}
+		return unmarshalOpts.Unmarshal(payload, msg)
+	default:
+		return fmt.Errorf("unsupported payload format: %d", format)
+	}
+}
+
+// findUnknownWireFields reports unknown fields preserved while decoding a binary payload,
+// for msg and every nested message, using the path of the message that contains them
+func findUnknownWireFields(msg protoreflect.Message) []ValidationError {
+	var errors []ValidationError
+	collectUnknownWireFields(msg, jsonLocation{}, &errors)
+	return errors
+}
+
+// collectUnknownWireFields appends an error for every distinct unknown field number in msg,
+// located at loc, and recurses into populated message fields
+func collectUnknownWireFields(msg protoreflect.Message, loc jsonLocation, errors *[]ValidationError) {
+	seen := make(map[protowire.Number]bool)
+	for unknown := msg.GetUnknown(); len(unknown) > 0; {
+		num, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			break
+		}
+		m := protowire.ConsumeFieldValue(num, typ, unknown[n:])
+		if m < 0 {
+			break
+		}
+		unknown = unknown[n+m:]
+
+		if seen[num] {
+			continue
+		}
+		seen[num] = true
+
+		fullName := msg.Descriptor().FullName()
+		location := loc.jsonField
+		if location == "" {
+			location = string(fullName)
+		}
+		*errors = append(*errors, ValidationError{
+			Friendly:  fmt.Sprintf("field number %d in '%s' is not defined in %s", num, location, fullName),
+			Technical: fmt.Sprintf("%s: unknown field number %d in %s", location, num, fullName),
+			Field:     loc.field,
+			JSONField: loc.jsonField,
+			Pointer:   pointerTo(loc.pointer),
+			RuleID:    unknownFieldRuleID,
+		})
+	}
+
+	msg.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		fieldLoc := loc.child(fd, fd.JSONName())
+
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				return true
+			}
+			value.Map().Range(func(key protoreflect.MapKey, entry protoreflect.Value) bool {
+				collectUnknownWireFields(entry.Message(), fieldLoc.index(strconv.Quote(key.String()), key.String()), errors)
+				return true
+			})
+		case fd.IsList():
+			if fd.Message() == nil {
+				return true
+			}
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				collectUnknownWireFields(list.Get(i).Message(), fieldLoc.index(strconv.Itoa(i), strconv.Itoa(i)), errors)
+			}
+		case fd.Message() != nil:
+			collectUnknownWireFields(value.Message(), fieldLoc, errors)
+		}
+		return true
+	})
+}
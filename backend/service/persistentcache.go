@@ -0,0 +1,369 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"validation-service/backend/logger"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// persistedLabelPattern matches the labels a PersistentCache records commits for, they are
+// file names in the cache directory; lookups of other labels bypass the cache
+var persistedLabelPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+
+// persistedSymbolPattern matches the fully qualified names a PersistentCache stores entries
+// for, they are part of file names in the cache directory; lookups of other names bypass
+// the cache
+var persistedSymbolPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// PersistentCache stores the descriptor sets and schemas fetched from BSR on disk, keyed
+// by module and commit, so they are reused across restarts and can be served when BSR
+// fails, see PersistentDescriptorSource and PersistentSchemaSource
+// The layout of a module's entries in dir is
+//
+//	{module}/commits/{commit}/descriptors/{symbol}.binpb
+//	{module}/commits/{commit}/jsonschema/{schema file}
+//	{module}/commits/{commit}/module-digest
+//	{module}/labels/{label}   (commit ID the label last resolved to)
+type PersistentCache struct {
+	dir          string
+	staleTimeout time.Duration
+}
+
+// NewPersistentCache creates a cache persisting to dir
+// staleTimeout is how long a lookup waits for BSR before serving the stale copy it has
+// for a label, 0 waits until BSR answers
+func NewPersistentCache(dir string, staleTimeout time.Duration) (*PersistentCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create persistent cache directory: %w", err)
+	}
+	logger.Debug("Initializing PersistentCache in %s with staleTimeout=%s", dir, staleTimeout)
+	return &PersistentCache{dir: dir, staleTimeout: staleTimeout}, nil
+}
+
+// commitPath returns the path of name in the entries of commit of module
+func (c *PersistentCache) commitPath(module, commit string, name ...string) string {
+	return filepath.Join(append([]string{c.dir, filepath.FromSlash(module), "commits", commit}, name...)...)
+}
+
+// write stores data at path, creating its directory
+func (c *PersistentCache) write(path string, data []byte) {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err == nil {
+		err = writeFileAtomic(path, data)
+	}
+	if err != nil {
+		// A failed write only costs the fallback, never the lookup
+		logger.Warn("Failed to write persistent cache entry %s: %v", path, err)
+	}
+}
+
+// labelCommit returns the commit ID label of module last resolved to, "" if unknown
+func (c *PersistentCache) labelCommit(module, label string) string {
+	data, err := os.ReadFile(filepath.Join(c.dir, filepath.FromSlash(module), "labels", label))
+	if err != nil {
+		return ""
+	}
+	commit := strings.TrimSpace(string(data))
+	if !isCommitID(commit) {
+		return ""
+	}
+	return commit
+}
+
+// putCommit records that label of module resolved to commit, with the module digest of commit
+func (c *PersistentCache) putCommit(module, label, commit, moduleDigest string) {
+	if label != commit {
+		c.write(filepath.Join(c.dir, filepath.FromSlash(module), "labels", label), []byte(commit))
+	}
+	if moduleDigest != "" {
+		c.write(c.commitPath(module, commit, "module-digest"), []byte(moduleDigest))
+	}
+}
+
+// moduleDigest returns the module digest stored for commit of module, "" if unknown
+func (c *PersistentCache) moduleDigest(module, commit string) string {
+	data, err := os.ReadFile(c.commitPath(module, commit, "module-digest"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// PutMessage stores the descriptors of resolved, fetched for symbol at the requested
+// version of module; resolutions without a concrete commit are not stored
+func (c *PersistentCache) PutMessage(module, requested, symbol string, resolved *ResolvedMessage) {
+	if !isCommitID(resolved.Commit) {
+		return
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	resolved.Files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+		return true
+	})
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(set)
+	if err != nil {
+		logger.Warn("Failed to encode descriptors of %s for the persistent cache: %v", symbol, err)
+		return
+	}
+	c.write(c.commitPath(module, resolved.Commit, "descriptors", symbol+registeredSetExtension), data)
+	c.putCommit(module, requested, resolved.Commit, resolved.ModuleDigest)
+}
+
+// GetMessage returns the stored descriptors of symbol at commit of module
+// Returns an error wrapping ErrNotFound if none are stored
+func (c *PersistentCache) GetMessage(module, commit, symbol string) (*ResolvedMessage, error) {
+	data, err := os.ReadFile(c.commitPath(module, commit, "descriptors", symbol+registeredSetExtension))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("descriptors of %s at %s %w in the persistent cache", symbol, commit, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	set, err := decodeFileDescriptorSet(data, false)
+	if err != nil {
+		return nil, err
+	}
+	files, err := newFilesFromSet(set)
+	if err != nil {
+		return nil, err
+	}
+	md, err := findMessageInFiles(files, symbol)
+	if err != nil {
+		return nil, err
+	}
+	return &ResolvedMessage{
+		Descriptor:   md,
+		Files:        files,
+		Source:       cachedSourceName,
+		Commit:       commit,
+		Digest:       descriptorDigest(md),
+		ModuleDigest: c.moduleDigest(module, commit),
+	}, nil
+}
+
+// staleMessage returns the descriptors of symbol stored for the commit label of module last
+// resolved to, flagged as stale, or nil if none are stored
+func (c *PersistentCache) staleMessage(module, label, symbol string) *ResolvedMessage {
+	commit := c.labelCommit(module, label)
+	if commit == "" {
+		return nil
+	}
+	stale, err := c.GetMessage(module, commit, symbol)
+	if err != nil {
+		return nil
+	}
+	stale.Stale = true
+	return stale
+}
+
+// PutSchema stores the schema file fileName, fetched at the requested version of module;
+// schemas without a concrete commit are not stored
+func (c *PersistentCache) PutSchema(module, requested, fileName string, schema *ResolvedSchema) {
+	if !isCommitID(schema.Commit) {
+		return
+	}
+	c.write(c.commitPath(module, schema.Commit, snapshotSchemaDir, fileName), schema.Data)
+	c.putCommit(module, requested, schema.Commit, schema.ModuleDigest)
+}
+
+// GetSchema returns the stored schema file fileName at commit of module
+// Returns an error wrapping ErrNotFound if it is not stored
+func (c *PersistentCache) GetSchema(module, commit, fileName string) (*ResolvedSchema, error) {
+	data, err := os.ReadFile(c.commitPath(module, commit, snapshotSchemaDir, fileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("schema %s at %s %w in the persistent cache", fileName, commit, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ResolvedSchema{Data: data, Source: cachedSourceName, Commit: commit, ModuleDigest: c.moduleDigest(module, commit)}, nil
+}
+
+// staleSchema returns the schema file fileName stored for the commit label of module last
+// resolved to, flagged as stale, or nil if it is not stored
+func (c *PersistentCache) staleSchema(module, label, fileName string) *ResolvedSchema {
+	commit := c.labelCommit(module, label)
+	if commit == "" {
+		return nil
+	}
+	stale, err := c.GetSchema(module, commit, fileName)
+	if err != nil {
+		return nil
+	}
+	stale.Stale = true
+	return stale
+}
+
+// persistable reports whether entries of symbol at label can be stored, see
+// persistedLabelPattern and persistedSymbolPattern
+func persistable(label, symbol string) bool {
+	return persistedLabelPattern.MatchString(label) && persistedSymbolPattern.MatchString(symbol)
+}
+
+// PersistentDescriptorSource persists the descriptors another source, e.g. BSR, resolves
+// to a PersistentCache
+// Lookups by commit ID are served from the cache once stored; lookups by label always ask
+// the source, and are answered with the copy stored for the commit the label last
+// resolved to, flagged as stale, if the source fails or does not answer within the
+// cache's stale timeout; the lookup keeps running in the background and refreshes the
+// copy once the source answers
+type PersistentDescriptorSource struct {
+	source  DescriptorSource
+	cache   *PersistentCache
+	module  string
+	flights flightGroup[*ResolvedMessage]
+}
+
+// NewPersistentDescriptorSource wraps source with cache
+// module namespaces the cache entries, e.g. the BSR module name
+func NewPersistentDescriptorSource(source DescriptorSource, cache *PersistentCache, module string) *PersistentDescriptorSource {
+	return &PersistentDescriptorSource{
+		source: source,
+		cache:  cache,
+		module: module,
+	}
+}
+
+// Name implements DescriptorSource
+func (s *PersistentDescriptorSource) Name() string {
+	return s.source.Name()
+}
+
+// FindMessage implements DescriptorSource
+func (s *PersistentDescriptorSource) FindMessage(schemaName, version string) (*ResolvedMessage, error) {
+	// An empty version is the source's default label, see BSRDescriptorSource.FindMessage
+	label := version
+	if label == "" {
+		label = defaultBSRLabel()
+	}
+	if !persistable(label, schemaName) {
+		logger.Debug("Not persisting descriptors of %s at %s, the names cannot be stored", schemaName, label)
+		return s.source.FindMessage(schemaName, version)
+	}
+
+	if isCommitID(version) {
+		if stored, err := s.cache.GetMessage(s.module, version, schemaName); err == nil {
+			logger.Debug("Using persisted descriptor for %s (module=%s, commit=%s)", schemaName, s.module, version)
+			return stored, nil
+		}
+	}
+
+	return fetchOrStale(&s.flights, label+"|"+schemaName, s.cache.staleTimeout, func() *ResolvedMessage {
+		return s.cache.staleMessage(s.module, label, schemaName)
+	}, func() (*ResolvedMessage, error) {
+		resolved, err := s.source.FindMessage(schemaName, version)
+		if err == nil {
+			s.cache.PutMessage(s.module, label, schemaName, resolved)
+		}
+		return resolved, err
+	})
+}
+
+// PersistentSchemaSource persists the schemas another source, e.g. BSR, returns to a
+// PersistentCache, with the semantics of PersistentDescriptorSource
+type PersistentSchemaSource struct {
+	source  SchemaSource
+	cache   *PersistentCache
+	module  string
+	flights flightGroup[*ResolvedSchema]
+}
+
+// NewPersistentSchemaSource wraps source with cache
+// module namespaces the cache entries, e.g. the BSR module name
+func NewPersistentSchemaSource(source SchemaSource, cache *PersistentCache, module string) *PersistentSchemaSource {
+	return &PersistentSchemaSource{
+		source: source,
+		cache:  cache,
+		module: module,
+	}
+}
+
+// Name implements SchemaSource
+func (s *PersistentSchemaSource) Name() string {
+	return s.source.Name()
+}
+
+// GetSchema implements SchemaSource
+func (s *PersistentSchemaSource) GetSchema(messageName, version string, variant SchemaVariant) (*ResolvedSchema, error) {
+	// An empty version is the source's default label, see BSRSchemaSource.GetSchema
+	label := version
+	if label == "" {
+		label = defaultBSRLabel()
+	}
+	if !persistable(label, messageName) {
+		logger.Debug("Not persisting schema of %s at %s, the names cannot be stored", messageName, label)
+		return s.source.GetSchema(messageName, version, variant)
+	}
+
+	fileName := variant.FileName(messageName)
+	if isCommitID(version) {
+		if stored, err := s.cache.GetSchema(s.module, version, fileName); err == nil {
+			logger.Debug("Using persisted schema %s (module=%s, commit=%s)", fileName, s.module, version)
+			return stored, nil
+		}
+	}
+
+	return fetchOrStale(&s.flights, label+"|"+fileName, s.cache.staleTimeout, func() *ResolvedSchema {
+		return s.cache.staleSchema(s.module, label, fileName)
+	}, func() (*ResolvedSchema, error) {
+		schema, err := s.source.GetSchema(messageName, version, variant)
+		if err == nil {
+			s.cache.PutSchema(s.module, label, fileName, schema)
+		}
+		return schema, err
+	})
+}
+
+// fetchOrStale runs fetch for key, coalesced with the running fetch for key if any
+// If fetch fails for any reason other than not knowing the message or version, or does
+// not finish within timeout, the stale copy loadStale returns, if any, is returned
+// instead; fetch then keeps running in the background, so the stored copy is revalidated
+// loadStale is only called then, a lookup the source answers never reads the stored copy
+func fetchOrStale[T comparable](flights *flightGroup[T], key string, timeout time.Duration, loadStale func() T, fetch func() (T, error)) (T, error) {
+	type result struct {
+		val T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		val, _, err := flights.Do(key, fetch)
+		done <- result{val, err}
+	}()
+
+	var none T
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case r := <-done:
+		if r.err == nil || errors.Is(r.err, ErrNotFound) || errors.Is(r.err, ErrVersionUnavailable) || errors.Is(r.err, ErrInvalidArgument) {
+			return r.val, r.err
+		}
+		if stale := loadStale(); stale != none {
+			logger.Warn("Serving stale copy of %s, the source failed: %v", key, r.err)
+			return stale, nil
+		}
+		return r.val, r.err
+	case <-expired:
+		if stale := loadStale(); stale != none {
+			logger.Warn("Serving stale copy of %s, the source did not answer within %s; revalidating in the background", key, timeout)
+			return stale, nil
+		}
+		// Without a stored copy there is nothing to serve meanwhile, wait for the source
+		r := <-done
+		return r.val, r.err
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+const testModule = "buf.build/org/module"
+
+func TestPersistentDescriptorSourceServesStaleCopies(t *testing.T) {
+	dir := t.TempDir()
+	cache, _ := NewPersistentCache(dir, time.Second)
+	fresh, err := NewPersistentDescriptorSource(&fakeDescriptorSource{name: "bsr"}, cache, testModule).FindMessage("proto.Task", "main")
+	if err != nil {
+		t.Fatalf("FindMessage failed: %v", err)
+	}
+	if fresh.Source != "bsr" || fresh.Stale {
+		t.Errorf("Expected a fresh resolution from bsr, got %+v", fresh.Resolution())
+	}
+
+	// After a restart with BSR down, the label is served from disk, flagged as stale
+	cache, _ = NewPersistentCache(dir, time.Second)
+	bsr := &fakeDescriptorSource{name: "bsr", err: errors.New("connection refused")}
+	source := NewPersistentDescriptorSource(bsr, cache, testModule)
+	stale, err := source.FindMessage("proto.Task", "main")
+	if err != nil {
+		t.Fatalf("Expected the stale copy, got %v", err)
+	}
+	if !stale.Stale || stale.Source != cachedSourceName || stale.Commit != testCommitID || stale.Digest != fresh.Digest {
+		t.Errorf("Expected the stale copy of commit %s, got %+v", testCommitID, stale.Resolution())
+	}
+
+	// A commit is immutable: it is served from disk without asking BSR and is never stale
+	calls := bsr.calls
+	pinned, err := source.FindMessage("proto.Task", testCommitID)
+	if err != nil || pinned.Stale || pinned.Source != cachedSourceName || bsr.calls != calls {
+		t.Errorf("Expected commit %s from disk without a BSR call, got %v, %v (calls %d)", testCommitID, pinned, err, bsr.calls-calls)
+	}
+
+	if _, err := source.FindMessage("proto.SimpleUser", "main"); err == nil {
+		t.Error("Expected a failure for a message that was never fetched")
+	}
+	bsr.err = ErrNotFound
+	if _, err := source.FindMessage("proto.Task", "main"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound from BSR to be returned, not the stale copy, got %v", err)
+	}
+	bsr.err = nil
+	if resolved, err := source.FindMessage("proto.Task", "main"); err != nil || resolved.Stale {
+		t.Errorf("Expected a fresh resolution once BSR is back, got %v, %v", resolved, err)
+	}
+}
+
+func TestPersistentSchemaSourceServesStaleCopies(t *testing.T) {
+	dir := t.TempDir()
+	cache, _ := NewPersistentCache(dir, time.Second)
+	bsr := &fakeBSR{labelCommit: testCommitID, requests: make(map[string]int)}
+	if _, err := NewPersistentSchemaSource(newFakeBSRSchemaSource(bsr), cache, testModule).GetSchema("proto.Task", "", SchemaVariant{}); err != nil {
+		t.Fatalf("GetSchema failed: %v", err)
+	}
+
+	down := &fakeBSR{unavailable: true, requests: make(map[string]int)}
+	source := NewPersistentSchemaSource(newFakeBSRSchemaSource(down), cache, testModule)
+	for _, version := range []string{"", "main"} {
+		schema, err := source.GetSchema("proto.Task", version, SchemaVariant{})
+		if err != nil {
+			t.Fatalf("Version %q: expected the stale copy, got %v", version, err)
+		}
+		if !schema.Stale || schema.Commit != testCommitID || schema.ModuleDigest != "b5:deadbeef" || string(schema.Data) != `{"type":"object"}` {
+			t.Errorf("Version %q: expected the stale copy of commit %s, got %+v", version, testCommitID, schema.Resolution())
+		}
+	}
+	requests := len(down.requests)
+	if schema, err := source.GetSchema("proto.Task", testCommitID, SchemaVariant{}); err != nil || schema.Stale || len(down.requests) != requests {
+		t.Errorf("Expected commit %s from disk without a BSR request, got %v (requests %v)", testCommitID, err, down.requests)
+	}
+	if _, err := source.GetSchema("proto.Task", "", SchemaVariant{Strict: true}); err == nil {
+		t.Error("Expected a failure for a variant that was never fetched")
+	}
+}
+
+func TestPersistentCacheBypassesUnsafeNames(t *testing.T) {
+	dir := t.TempDir()
+	cache, _ := NewPersistentCache(dir, time.Second)
+	bsr := &fakeDescriptorSource{name: "bsr"}
+	descriptors := NewPersistentDescriptorSource(bsr, cache, testModule)
+	released := make(chan struct{})
+	close(released)
+	schemas := NewPersistentSchemaSource(&slowSchemaSource{release: released, data: `{}`}, cache, testModule)
+
+	// Names that cannot be file names are passed to the source without touching the cache
+	for i, lookup := range []struct{ symbol, version string }{{"proto.Task", ".."}, {"proto.Task", "."}, {"../proto.Task", "main"}} {
+		descriptors.FindMessage(lookup.symbol, lookup.version)
+		if bsr.calls != i+1 {
+			t.Errorf("%s at %q: expected the source to be asked", lookup.symbol, lookup.version)
+		}
+		if _, err := schemas.GetSchema(lookup.symbol, lookup.version, SchemaVariant{}); err != nil {
+			t.Errorf("%s at %q: expected the source's schema, got %v", lookup.symbol, lookup.version, err)
+		}
+	}
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 0 {
+		t.Errorf("Expected nothing to be persisted, got %v, %v", entries, err)
+	}
+}
+
+// slowSchemaSource returns data once release is closed
+type slowSchemaSource struct {
+	release chan struct{}
+	data    string
+}
+
+func (s *slowSchemaSource) Name() string {
+	return "bsr"
+}
+
+func (s *slowSchemaSource) GetSchema(messageName, version string, variant SchemaVariant) (*ResolvedSchema, error) {
+	<-s.release
+	return &ResolvedSchema{Data: []byte(s.data), Source: s.Name(), Commit: testCommitID}, nil
+}
+
+func TestPersistentSchemaSourceRevalidatesInTheBackground(t *testing.T) {
+	cache, _ := NewPersistentCache(t.TempDir(), 10*time.Millisecond)
+	released := make(chan struct{})
+	close(released)
+	if _, err := NewPersistentSchemaSource(&slowSchemaSource{release: released, data: `{"v":1}`}, cache, testModule).GetSchema("proto.Task", "main", SchemaVariant{}); err != nil {
+		t.Fatalf("GetSchema failed: %v", err)
+	}
+
+	// BSR does not answer within the timeout: the stale copy is served meanwhile
+	slow := &slowSchemaSource{release: make(chan struct{}), data: `{"v":2}`}
+	source := NewPersistentSchemaSource(slow, cache, testModule)
+	schema, err := source.GetSchema("proto.Task", "main", SchemaVariant{})
+	if err != nil || !schema.Stale || string(schema.Data) != `{"v":1}` {
+		t.Fatalf("Expected the stale copy after the timeout, got %v, %v", schema, err)
+	}
+
+	// The fetch completes in the background and refreshes the stored copy
+	close(slow.release)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stored, err := cache.GetSchema(testModule, testCommitID, SchemaVariant{}.FileName("proto.Task"))
+		if err == nil && string(stored.Data) == `{"v":2}` {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the stored copy to be revalidated, got %v, %v", stored, err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if schema, err := source.GetSchema("proto.Task", "main", SchemaVariant{}); err != nil || schema.Stale || string(schema.Data) != `{"v":2}` {
+		t.Errorf("Expected the revalidated schema, got %v, %v", schema, err)
+	}
+}
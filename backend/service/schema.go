@@ -1,105 +1,116 @@
 package service
 
 import (
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
-	"os"
-	"path/filepath"
 	"regexp"
 	"strings"
-	"validation-service/backend/config"
 	"validation-service/backend/logger"
 
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
-// SchemaService handles schema retrieval from local filesystem or BSR
+// SchemaService handles schema retrieval from local filesystem, BSR or generated from descriptors
 type SchemaService struct {
-	bsrOrg           string
-	bsrModule        string
-	basePath         string
-	httpClient       *http.Client
-	schemaSourceMode config.SchemaSourceMode
-	bsrToken         string
+	schemas SchemaSource
+	files   []FileLister
+}
+
+// FileLister is implemented by descriptor sources whose messages ListProtoFiles lists
+type FileLister interface {
+	// RangeFiles calls f with the files of each version the source serves, "" for the
+	// default version, until f returns false
+	RangeFiles(f func(version string, files *protoregistry.Files) bool)
 }
 
 // NewSchemaService creates a new schema service instance
-func NewSchemaService(bsrOrg, bsrModule, basePath string, schemaSourceMode config.SchemaSourceMode) *SchemaService {
-	bsrToken := config.GetEnv("BUF_TOKEN", "")
-	if bsrToken == "" {
-		logger.Warn("BUF_TOKEN is not set. BSR requests may fail for private repositories.")
-	} else {
-		logger.Debug("BUF_TOKEN is set (length: %d)", len(bsrToken))
+// schemas retrieves the JSON Schema documents, see NewSchemaSourceForMode
+// files are the sources ListProtoFiles lists, by default the compiled-in types
+func NewSchemaService(schemas SchemaSource, files ...FileLister) *SchemaService {
+	logger.Debug("Initializing SchemaService with source=%s", schemas.Name())
+	if len(files) == 0 {
+		files = []FileLister{NewRegistryDescriptorSource(nil)}
 	}
-	logger.Debug("Initializing SchemaService with org=%s, module=%s, basePath=%s, mode=%d", bsrOrg, bsrModule, basePath, schemaSourceMode)
 	return &SchemaService{
-		bsrOrg:           bsrOrg,
-		bsrModule:        bsrModule,
-		basePath:         basePath,
-		httpClient:       &http.Client{},
-		schemaSourceMode: schemaSourceMode,
-		bsrToken:         bsrToken,
+		schemas: schemas,
+		files:   files,
 	}
 }
 
-// GetSchema retrieves the JSON schema for a given message name
-// Behavior depends on schemaSourceMode:
-// - BSROnly: Fetches directly from BSR (skips local check)
-// - LocalOnly: Only checks local files (never fetches from BSR)
-// - LocalThenBSR: Checks local first, then falls back to BSR
-func (s *SchemaService) GetSchema(messageName string) ([]byte, error) {
-	logger.Debug("GetSchema called for messageName=%s, mode=%d", messageName, s.schemaSourceMode)
+// SchemaOptions selects the form of the schema returned by GetSchema
+type SchemaOptions struct {
+	Variant SchemaVariant
+
+	// ResolveRefs bundles an unbundled variant and the files it references into a single
+	// document, like the generated bundles; it has no effect on bundled variants
+	ResolveRefs bool
+}
+
+// GetSchema retrieves the JSON schema for a given message name from the schema source
+// commit is the commit ID or label the schema is generated from (defaults to "main" if empty),
+// so the schema matches the definitions ValidatePayload uses for the same commit
+// The result records the source that returned the schema, its commit if known, and its digest
+func (s *SchemaService) GetSchema(messageName, commit string, opts SchemaOptions) (*ResolvedSchema, error) {
+	// Set default commit to "main" if not provided
+	if commit == "" {
+		commit = "main"
+	}
+	logger.Debug("GetSchema called for messageName=%s, commit=%s, variant=%s, resolveRefs=%v, source=%s", messageName, commit, opts.Variant, opts.ResolveRefs, s.schemas.Name())
 
 	// Validate message name format
 	if err := s.validateMessageName(messageName); err != nil {
 		logger.Debug("Message name validation failed for %s: %v", messageName, err)
-		return nil, fmt.Errorf("invalid message name: %w", err)
+		return nil, fmt.Errorf("%w: invalid message name: %w", ErrInvalidArgument, err)
 	}
 	logger.Debug("Message name validation passed for %s", messageName)
 
-	// Handle BSROnly mode: skip local check, fetch directly from BSR
-	if s.schemaSourceMode == config.BSROnly {
-		logger.Debug("BSROnly mode: fetching schema directly from BSR for %s", messageName)
-		schema, err := s.fetchFromBSR(messageName)
-		if err != nil {
-			logger.Error("Failed to fetch schema from BSR for %s: %v", messageName, err)
-			return nil, fmt.Errorf("failed to fetch from BSR: %w", err)
-		}
-		logger.Info("Successfully fetched schema from BSR for %s (size: %d bytes)", messageName, len(schema))
-		return schema, nil
+	schema, err := s.fetchSchema(messageName, commit, opts.Variant)
+	if err != nil {
+		return nil, err
 	}
 
-	// Handle LocalOnly mode: only check local files, never fetch from BSR
-	if s.schemaSourceMode == config.LocalOnly {
-		logger.Debug("LocalOnly mode: checking local filesystem for schema: %s", messageName)
-		schema, found := s.checkLocalSchema(messageName)
-		if !found {
-			logger.Error("Schema not found locally for %s and LocalOnly mode is enabled", messageName)
-			return nil, fmt.Errorf("schema not found locally for %s", messageName)
+	if opts.ResolveRefs && opts.Variant.Unbundled {
+		// Referenced files are fetched at the version the root resolved to, so they come
+		// from the same source and commit as the root
+		refCommit := commit
+		if schema.Commit != "" {
+			refCommit = schema.Commit
+		}
+		bundled, err := bundleSchemaRefs(messageName, schema.Data, opts.Variant, func(refName string) ([]byte, error) {
+			ref, err := s.fetchSchema(refName, refCommit, opts.Variant)
+			if err != nil {
+				return nil, err
+			}
+			return ref.Data, nil
+		})
+		if err != nil {
+			logger.Error("Failed to resolve schema refs for %s: %v", messageName, err)
+			return nil, fmt.Errorf("failed to resolve schema for %s: %w", messageName, err)
 		}
-		logger.Info("Schema found locally for %s (size: %d bytes)", messageName, len(schema))
-		return schema, nil
+		schema.Data = bundled
 	}
 
-	// Handle LocalThenBSR mode (default): check local first, then fallback to BSR
-	logger.Debug("LocalThenBSR mode: checking local filesystem first for schema: %s", messageName)
-	schema, found := s.checkLocalSchema(messageName)
-	if found {
-		logger.Info("Schema found locally for %s (size: %d bytes)", messageName, len(schema))
-		return schema, nil
-	}
-	logger.Debug("Schema not found locally for %s, attempting BSR fetch", messageName)
+	schema.Digest = contentDigest(schema.Data)
+	logger.Info("Successfully retrieved schema for %s from source=%s (variant: %s, size: %d bytes)", messageName, schema.Source, opts.Variant, len(schema.Data))
+	return schema, nil
+}
 
-	// If not found locally, fetch from BSR
-	logger.Debug("Fetching schema from BSR for %s", messageName)
-	schema, err := s.fetchFromBSR(messageName)
+// fetchSchema retrieves one schema file from the schema source and maps source errors
+func (s *SchemaService) fetchSchema(messageName, commit string, variant SchemaVariant) (*ResolvedSchema, error) {
+	schema, err := s.schemas.GetSchema(messageName, commit, variant)
+	if errors.Is(err, ErrVersionUnavailable) {
+		logger.Debug("Commit %s not available for messageName=%s: %v", commit, messageName, err)
+		return nil, fmt.Errorf("commit %s is not available for %s: %w", commit, messageName, err)
+	}
+	if errors.Is(err, ErrNotFound) {
+		logger.Debug("Schema not found for %s: %v", messageName, err)
+		return nil, fmt.Errorf("schema not found for %s (%s): %w", messageName, variant, ErrNotFound)
+	}
 	if err != nil {
-		logger.Error("Failed to fetch schema from BSR for %s: %v", messageName, err)
-		return nil, fmt.Errorf("failed to fetch from BSR: %w", err)
+		logger.Error("Failed to fetch schema for %s: %v", messageName, err)
+		return nil, fmt.Errorf("%w: failed to fetch schema for %s: %w", ErrSourceUnavailable, messageName, err)
 	}
-	logger.Info("Successfully fetched schema from BSR for %s (size: %d bytes)", messageName, len(schema))
 
 	return schema, nil
 }
@@ -130,96 +141,26 @@ func (s *SchemaService) validateMessageName(messageName string) error {
 	return nil
 }
 
-// checkLocalSchema checks if the schema exists locally
-func (s *SchemaService) checkLocalSchema(messageName string) ([]byte, bool) {
-	// Construct local file path: gen/jsonschema/{FULL_NAME}.schema.bundle.json
-	schemaPath := filepath.Join(s.basePath, "gen", "jsonschema", fmt.Sprintf("%s.schema.bundle.json", messageName))
-	logger.Debug("Checking local schema path: %s", schemaPath)
-
-	data, err := os.ReadFile(schemaPath)
-	if err != nil {
-		logger.Debug("Local schema file not found or unreadable: %s (error: %v)", schemaPath, err)
-		return nil, false
-	}
-
-	logger.Debug("Local schema file found and read successfully: %s", schemaPath)
-	return data, true
-}
-
-// fetchFromBSR fetches the schema directly from BSR via HTTP
-func (s *SchemaService) fetchFromBSR(messageName string) ([]byte, error) {
-	url := s.buildBSRURL(messageName)
-	logger.Debug("Fetching from BSR URL: %s", url)
-
-	// Create HTTP request
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		logger.Error("Failed to create HTTP request for URL %s: %v", url, err)
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Add Bearer token authentication if available
-	if s.bsrToken != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.bsrToken))
-		logger.Debug("Added Bearer token to BSR request")
-	}
-
-	// Execute the request
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		logger.Error("HTTP GET request failed for URL %s: %v", url, err)
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	logger.Debug("BSR HTTP response status: %d %s", resp.StatusCode, resp.Status)
-
-	if resp.StatusCode == http.StatusNotFound {
-		logger.Debug("Schema not found in BSR (404) for messageName=%s", messageName)
-		return nil, fmt.Errorf("schema not found in BSR")
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		logger.Error("BSR returned unexpected status code %d for messageName=%s", resp.StatusCode, messageName)
-		return nil, fmt.Errorf("BSR returned status code %d", resp.StatusCode)
-	}
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		logger.Error("Failed to read BSR response body: %v", err)
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	logger.Debug("Successfully read BSR response body (size: %d bytes)", len(data))
-	return data, nil
-}
-
-// buildBSRURL constructs the BSR URL for fetching the schema
-func (s *SchemaService) buildBSRURL(messageName string) string {
-	// URL format: https://buf.build/gen/archive/{org}/{module}/bufbuild/protoschema-jsonschema/raw/latest/{FULL_NAME}.schema.bundle.json
-	url := fmt.Sprintf(
-		"https://buf.build/gen/archive/%s/%s/bufbuild/protoschema-jsonschema/raw/latest/%s.schema.bundle.json",
-		s.bsrOrg,
-		s.bsrModule,
-		messageName,
-	)
-	logger.Debug("Built BSR URL for messageName=%s: %s", messageName, url)
-	return url
-}
-
 // ProtoFile represents a proto message file with metadata
 type ProtoFile struct {
 	Name               string `json:"name"`
 	Description        string `json:"description"`
 	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Version            string `json:"version,omitempty"` // Version to request the message with, empty for the default version
 }
 
-// ListProtoFiles enumerates all available proto message types from the protobuf registry
+// ListProtoFiles enumerates all available proto message types from the registries of the
+// file listers, each message once per version it is available in
+// The compiled-in registry also holds the service's own API and its dependencies, so only
+// its messages in the "proto" namespace are listed; other registries list every message
+// except those of buf/validate and the well-known types
 func (s *SchemaService) ListProtoFiles() ([]ProtoFile, error) {
 	logger.Debug("ListProtoFiles called")
 
 	var protoFiles []ProtoFile
 	seenMessages := make(map[string]bool) // Track seen messages to avoid duplicates
+	version := ""                         // Version of the registry being walked
+	allNamespaces := false                // Whether the registry being walked lists every namespace
 
 	// Recursively walk nested message descriptors
 	var walkMessages func(md protoreflect.MessageDescriptor)
@@ -227,7 +168,7 @@ func (s *SchemaService) ListProtoFiles() ([]ProtoFile, error) {
 		fullyQualifiedName := string(md.FullName())
 
 		// Only include messages in the "proto" namespace
-		if !strings.HasPrefix(fullyQualifiedName, "proto.") {
+		if !allNamespaces && !strings.HasPrefix(fullyQualifiedName, "proto.") {
 			// logger.Debug("Skipping message not in proto namespace: %s", fullyQualifiedName)
 			// Still process nested messages in case they're in proto namespace
 			nested := md.Messages()
@@ -238,10 +179,10 @@ func (s *SchemaService) ListProtoFiles() ([]ProtoFile, error) {
 		}
 
 		// Skip if we've already seen this message
-		if seenMessages[fullyQualifiedName] {
+		if seenMessages[version+"/"+fullyQualifiedName] {
 			return
 		}
-		seenMessages[fullyQualifiedName] = true
+		seenMessages[version+"/"+fullyQualifiedName] = true
 
 		// Get the message name (last part of the fully qualified name)
 		name := string(md.Name())
@@ -285,9 +226,10 @@ func (s *SchemaService) ListProtoFiles() ([]ProtoFile, error) {
 			Name:               s.formatMessageName(name),
 			Description:        description,
 			FullyQualifiedName: fullyQualifiedName,
+			Version:            version,
 		})
 
-		logger.Debug("Found proto message: %s (%s)", fullyQualifiedName, name)
+		logger.Debug("Found proto message: %s (%s, version %q)", fullyQualifiedName, name, version)
 
 		// Recursively process nested messages
 		nested := md.Messages()
@@ -296,15 +238,25 @@ func (s *SchemaService) ListProtoFiles() ([]ProtoFile, error) {
 		}
 	}
 
-	// Iterate through all file descriptors in the global registry
-	protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
-		// For each file, get all top-level messages
-		msgs := fd.Messages()
-		for i := 0; i < msgs.Len(); i++ {
-			walkMessages(msgs.Get(i))
-		}
-		return true // Continue iteration
-	})
+	// Iterate through all file descriptors of every registry
+	for _, lister := range s.files {
+		lister.RangeFiles(func(registryVersion string, files *protoregistry.Files) bool {
+			version = registryVersion
+			allNamespaces = files != protoregistry.GlobalFiles
+			files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+				if allNamespaces && isCompiledInImport(fd.Path()) {
+					return true
+				}
+				// For each file, get all top-level messages
+				msgs := fd.Messages()
+				for i := 0; i < msgs.Len(); i++ {
+					walkMessages(msgs.Get(i))
+				}
+				return true // Continue iteration
+			})
+			return true
+		})
+	}
 
 	logger.Info("ListProtoFiles found %d proto message(s)", len(protoFiles))
 	return protoFiles, nil
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"validation-service/backend/logger"
+)
+
+// schemaDefsPrefix is the JSON Pointer prefix of bundled schema definitions
+const schemaDefsPrefix = "#/$defs/"
+
+// bundleSchemaRefs resolves the "$ref"s between the unbundled schema files of variant into a
+// single document, laid out like the generated bundles: every file is a "$defs" entry and the
+// root refers to its own entry
+// fetch returns the schema of a referenced message, refs that are not files of variant
+// (e.g. absolute URLs) are left untouched
+func bundleSchemaRefs(messageName string, root []byte, variant SchemaVariant, fetch func(messageName string) ([]byte, error)) ([]byte, error) {
+	rootFile := variant.FileName(messageName)
+	rootDoc, err := decodeSchemaDocument(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema %s: %w", rootFile, err)
+	}
+
+	defs := map[string]interface{}{}
+	pending := []string{rootFile}
+	docs := map[string]map[string]interface{}{rootFile: rootDoc}
+	for len(pending) > 0 {
+		file := pending[0]
+		pending = pending[1:]
+		if _, done := defs[file]; done {
+			continue
+		}
+
+		doc, ok := docs[file]
+		if !ok {
+			refName, _ := variant.messageName(file)
+			logger.Debug("Resolving schema $ref %s", file)
+			data, err := fetch(refName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve $ref %s: %w", file, err)
+			}
+			if doc, err = decodeSchemaDocument(data); err != nil {
+				return nil, fmt.Errorf("failed to parse schema %s: %w", file, err)
+			}
+		}
+
+		delete(doc, "$id")
+		defs[file] = doc
+		rewriteSchemaRefs(doc, variant, func(ref string) {
+			if _, done := defs[ref]; !done {
+				pending = append(pending, ref)
+			}
+		})
+	}
+	logger.Debug("Resolved %d schema file(s) for %s", len(defs), rootFile)
+
+	bundle := map[string]interface{}{
+		"$id":   SchemaVariant{JSONNames: variant.JSONNames, Strict: variant.Strict}.FileName(messageName),
+		"$ref":  schemaDefsPrefix + rootFile,
+		"$defs": defs,
+	}
+	if draft, ok := rootDoc["$schema"]; ok {
+		bundle["$schema"] = draft
+	}
+	return json.MarshalIndent(bundle, "", "  ")
+}
+
+// rewriteSchemaRefs points every "$ref" to a file of variant in value at its "$defs" entry,
+// calling found with each referenced file name
+func rewriteSchemaRefs(value interface{}, variant SchemaVariant, found func(file string)) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				if _, isFile := variant.messageName(ref); isFile && !strings.HasPrefix(ref, "#") {
+					v[key] = schemaDefsPrefix + ref
+					found(ref)
+				}
+				continue
+			}
+			rewriteSchemaRefs(child, variant, found)
+		}
+	case []interface{}:
+		for _, child := range v {
+			rewriteSchemaRefs(child, variant, found)
+		}
+	}
+}
+
+// decodeSchemaDocument decodes a JSON Schema object, keeping numbers exact
+func decodeSchemaDocument(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc map[string]interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("schema is not an object")
+	}
+	return doc, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// generatedSchemaDir holds the protoschema-jsonschema output checked into the repository
+var generatedSchemaDir = filepath.Join("..", "gen", "jsonschema")
+
+func TestSchemaVariantFileName(t *testing.T) {
+	tests := map[SchemaVariant]string{
+		{}:                "proto.Task.schema.bundle.json",
+		{JSONNames: true}: "proto.Task.jsonschema.bundle.json",
+		{Strict: true}:    "proto.Task.schema.strict.bundle.json",
+		{Unbundled: true}: "proto.Task.schema.json",
+		{JSONNames: true, Strict: true, Unbundled: true}: "proto.Task.jsonschema.strict.json",
+	}
+	for variant, want := range tests {
+		if got := variant.FileName("proto.Task"); got != want {
+			t.Errorf("%+v: expected %s, got %s", variant, want, got)
+		}
+		if name, ok := variant.messageName(want); !ok || name != "proto.Task" {
+			t.Errorf("%+v: failed to parse %s back, got %q", variant, want, name)
+		}
+	}
+}
+
+// TestSchemaServiceResolveRefsMatchesGeneratedBundle bundles the unbundled files of every
+// variant and compares the result with the bundle generated for the same variant
+func TestSchemaServiceResolveRefsMatchesGeneratedBundle(t *testing.T) {
+	svc := NewSchemaService(NewLocalSchemaSource(generatedSchemaDir))
+
+	for _, variant := range []SchemaVariant{{}, {JSONNames: true}, {Strict: true}, {JSONNames: true, Strict: true}} {
+		for _, messageName := range []string{"proto.ComplexOrder", "proto.EmployeeProfile"} {
+			unbundled := variant
+			unbundled.Unbundled = true
+
+			resolved, err := svc.GetSchema(messageName, "", SchemaOptions{Variant: unbundled, ResolveRefs: true})
+			if err != nil {
+				t.Fatalf("GetSchema(%s, %s) failed: %v", messageName, unbundled, err)
+			}
+			generated, err := os.ReadFile(filepath.Join(generatedSchemaDir, variant.FileName(messageName)))
+			if err != nil {
+				t.Fatalf("Failed to read generated bundle: %v", err)
+			}
+
+			var got, want interface{}
+			if err := json.Unmarshal(resolved.Data, &got); err != nil {
+				t.Fatalf("Resolved schema is not JSON: %v", err)
+			}
+			if err := json.Unmarshal(generated, &want); err != nil {
+				t.Fatalf("Generated schema is not JSON: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Resolved %s (%s) does not match the generated bundle", messageName, unbundled)
+			}
+		}
+	}
+}
+
+func TestSchemaServiceServesUnbundledVariantAsIs(t *testing.T) {
+	svc := NewSchemaService(NewLocalSchemaSource(generatedSchemaDir))
+
+	resolved, err := svc.GetSchema("proto.ComplexOrder", "", SchemaOptions{Variant: SchemaVariant{JSONNames: true, Unbundled: true}})
+	if err != nil {
+		t.Fatalf("GetSchema failed: %v", err)
+	}
+	generated, err := os.ReadFile(filepath.Join(generatedSchemaDir, "proto.ComplexOrder.jsonschema.json"))
+	if err != nil {
+		t.Fatalf("Failed to read generated schema: %v", err)
+	}
+	if string(resolved.Data) != string(generated) {
+		t.Errorf("Expected the generated file to be served unchanged")
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaVariant selects one of the JSON Schema files protoschema-jsonschema generates for
+// every message; the zero value is the default, {FULL_NAME}.schema.bundle.json
+type SchemaVariant struct {
+	JSONNames bool // Properties use JSON (lowerCamelCase) names instead of proto field names
+	Strict    bool // Only the canonical JSON encoding of each field is accepted
+	Unbundled bool // Nested messages are "$ref"s to their own files instead of "$defs"
+}
+
+// Field name styles accepted by ParseFieldNames
+const (
+	FieldNamesProto = "proto"
+	FieldNamesJSON  = "json"
+)
+
+// ParseFieldNames parses a field name style, "proto" (the default if empty) or "json",
+// and reports whether it selects JSON names
+func ParseFieldNames(names string) (bool, error) {
+	switch names {
+	case "", FieldNamesProto:
+		return false, nil
+	case FieldNamesJSON:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid field names %q: must be %q or %q", names, FieldNamesProto, FieldNamesJSON)
+	}
+}
+
+// FileSuffix returns the file name suffix of the variant, e.g. "jsonschema.strict.json"
+func (v SchemaVariant) FileSuffix() string {
+	parts := []string{"schema"}
+	if v.JSONNames {
+		parts[0] = "jsonschema"
+	}
+	if v.Strict {
+		parts = append(parts, "strict")
+	}
+	if !v.Unbundled {
+		parts = append(parts, "bundle")
+	}
+	return strings.Join(append(parts, "json"), ".")
+}
+
+// FileName returns the name of the file holding the variant of messageName's schema,
+// e.g. "proto.Task.schema.bundle.json"
+func (v SchemaVariant) FileName(messageName string) string {
+	return messageName + "." + v.FileSuffix()
+}
+
+// messageName returns the message whose schema fileName holds in this variant,
+// e.g. "proto.Task" for "proto.Task.schema.json", or false if fileName is another variant
+func (v SchemaVariant) messageName(fileName string) (string, bool) {
+	suffix := "." + v.FileSuffix()
+	if !strings.HasSuffix(fileName, suffix) {
+		return "", false
+	}
+	return strings.TrimSuffix(fileName, suffix), true
+}
+
+// String describes the variant in logs
+func (v SchemaVariant) String() string {
+	return v.FileSuffix()
+}
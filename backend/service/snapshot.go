@@ -0,0 +1,347 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"validation-service/backend/logger"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Files of a snapshot bundle, see CreateSnapshot
+const (
+	snapshotManifestFile = "snapshot.json"
+	snapshotImageFile    = "image.binpb"
+	snapshotSchemaDir    = "jsonschema"
+)
+
+// snapshotWorkers is the number of schema artifacts CreateSnapshot downloads concurrently
+const snapshotWorkers = 8
+
+// allSchemaVariants are the variants protoschema-jsonschema generates with target=all
+var allSchemaVariants = func() []SchemaVariant {
+	var variants []SchemaVariant
+	for _, jsonNames := range []bool{false, true} {
+		for _, strict := range []bool{false, true} {
+			for _, unbundled := range []bool{false, true} {
+				variants = append(variants, SchemaVariant{JSONNames: jsonNames, Strict: strict, Unbundled: unbundled})
+			}
+		}
+	}
+	return variants
+}()
+
+// SnapshotManifest describes a snapshot bundle, stored in it as snapshot.json
+type SnapshotManifest struct {
+	Module       string    `json:"module"`                 // e.g. buf.build/{org}/{module}
+	Version      string    `json:"version"`                // Commit ID or label the snapshot was requested for
+	Commit       string    `json:"commit"`                 // Commit Version resolved to
+	ModuleDigest string    `json:"moduleDigest,omitempty"` // BSR module digest of Commit
+	Schemas      int       `json:"schemas"`                // Number of schema artifacts in the bundle
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// CreateSnapshot downloads the FileDescriptorSet of the module at version, a commit ID or
+// label, and the protoschema-jsonschema artifacts of its messages into a bundle in dir,
+// so the snapshot source can serve them without network access
+// The bundle is the directory dir/{commit} holding snapshot.json, image.binpb and the
+// artifacts in jsonschema/; an existing bundle of the same commit is replaced
+// Returns the manifest and the path of the bundle
+func CreateSnapshot(descriptors *BSRDescriptorSource, schemas *BSRSchemaSource, version, dir string) (*SnapshotManifest, string, error) {
+	if version == "" {
+		version = "main"
+	}
+	logger.Info("Creating snapshot of %s at %s", descriptors.ModuleName(), version)
+
+	image, commit, err := descriptors.FetchImage(version)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch descriptors: %w", err)
+	}
+	if !isCommitID(commit) {
+		return nil, "", fmt.Errorf("BSR did not resolve %s to a commit, got %q", version, commit)
+	}
+	files, err := newFilesFromSet(image)
+	if err != nil {
+		return nil, "", err
+	}
+	imageData, err := proto.MarshalOptions{Deterministic: true}.Marshal(image)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode descriptors: %w", err)
+	}
+
+	// Assemble the bundle next to its final location, then swap it in
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	staging, err := os.MkdirTemp(dir, "."+commit+".*")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+	if err := os.Mkdir(filepath.Join(staging, snapshotSchemaDir), 0o755); err != nil {
+		return nil, "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(staging, snapshotImageFile), imageData, 0o644); err != nil {
+		return nil, "", fmt.Errorf("failed to write descriptors: %w", err)
+	}
+
+	count, err := downloadSchemas(schemas, files, commit, filepath.Join(staging, snapshotSchemaDir))
+	if err != nil {
+		return nil, "", err
+	}
+
+	manifest := &SnapshotManifest{
+		Module:       descriptors.ModuleName(),
+		Version:      version,
+		Commit:       commit,
+		ModuleDigest: lookupModuleDigest(descriptors.commits, commit),
+		Schemas:      count,
+		CreatedAt:    time.Now().UTC(),
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode snapshot manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(staging, snapshotManifestFile), manifestData, 0o644); err != nil {
+		return nil, "", fmt.Errorf("failed to write snapshot manifest: %w", err)
+	}
+
+	bundle := filepath.Join(dir, commit)
+	if err := os.RemoveAll(bundle); err != nil {
+		return nil, "", fmt.Errorf("failed to replace snapshot: %w", err)
+	}
+	if err := os.Rename(staging, bundle); err != nil {
+		return nil, "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	logger.Info("Created snapshot of %s at %s (commit %s) with %d schema(s) in %s", manifest.Module, version, commit, count, bundle)
+	return manifest, bundle, nil
+}
+
+// downloadSchemas downloads every variant of the schema of every message of files at
+// commit into dir, skipping the ones that were not generated (e.g. for dependencies)
+// Returns the number of artifacts downloaded
+func downloadSchemas(schemas *BSRSchemaSource, files *protoregistry.Files, commit, dir string) (int, error) {
+	type artifact struct {
+		messageName string
+		variant     SchemaVariant
+	}
+	var artifacts []artifact
+	for _, messageName := range listMessageNames(files) {
+		for _, variant := range allSchemaVariants {
+			artifacts = append(artifacts, artifact{messageName, variant})
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		count    int
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	indexes := make(chan int)
+	for w := 0; w < snapshotWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fileName := artifacts[i].variant.FileName(artifacts[i].messageName)
+				schema, err := schemas.GetSchema(artifacts[i].messageName, commit, artifacts[i].variant)
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				if err == nil {
+					err = os.WriteFile(filepath.Join(dir, fileName), schema.Data, 0o644)
+				}
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("failed to download %s: %w", fileName, err)
+				} else if err == nil {
+					count++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range artifacts {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return 0, firstErr
+	}
+	return count, nil
+}
+
+// listMessageNames returns the full names of the messages of files, except those of
+// buf/validate and the well-known types, sorted
+func listMessageNames(files *protoregistry.Files) []string {
+	var names []string
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		if !isCompiledInImport(fd.Path()) {
+			names = appendMessageNames(names, fd.Messages())
+		}
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+// SnapshotSource serves descriptors and schemas from the bundles CreateSnapshot writes,
+// without network access
+// A version is served by the bundle of that commit, or by the most recent bundle
+// snapshotted for that label; "" is served as "main", and "main" is served by the most
+// recent bundle if no bundle was snapshotted for it, since the services request "main"
+// when no version is given
+type SnapshotSource struct {
+	dir     string
+	bundles []*snapshotBundle // Most recent first
+}
+
+// snapshotBundle is a loaded snapshot bundle
+type snapshotBundle struct {
+	path     string
+	manifest SnapshotManifest
+	files    *protoregistry.Files
+	digests  sync.Map // Message full name to descriptorDigest, a bundle never changes
+}
+
+// NewSnapshotSource loads the bundles in dir
+// Returns an error if dir has no bundles or a bundle cannot be loaded
+func NewSnapshotSource(dir string) (*SnapshotSource, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+
+	source := &SnapshotSource{dir: dir}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		bundle, err := loadSnapshotBundle(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		source.bundles = append(source.bundles, bundle)
+	}
+	if len(source.bundles) == 0 {
+		return nil, fmt.Errorf("no snapshots found in %s", dir)
+	}
+	sort.SliceStable(source.bundles, func(i, j int) bool {
+		return source.bundles[i].manifest.CreatedAt.After(source.bundles[j].manifest.CreatedAt)
+	})
+	for _, bundle := range source.bundles {
+		logger.Info("Loaded snapshot of %s at %s (commit %s) from %s", bundle.manifest.Module, bundle.manifest.Version, bundle.manifest.Commit, bundle.path)
+	}
+	return source, nil
+}
+
+// loadSnapshotBundle loads the bundle in path
+func loadSnapshotBundle(path string) (*snapshotBundle, error) {
+	data, err := os.ReadFile(filepath.Join(path, snapshotManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot manifest: %w", err)
+	}
+	bundle := &snapshotBundle{path: path}
+	if err := json.Unmarshal(data, &bundle.manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot manifest %s: %w", path, err)
+	}
+	bundle.files, err = loadImages([]string{filepath.Join(path, snapshotImageFile)})
+	if err != nil {
+		return nil, fmt.Errorf("snapshot %s: %w", path, err)
+	}
+	return bundle, nil
+}
+
+// Name implements DescriptorSource and SchemaSource
+func (s *SnapshotSource) Name() string {
+	return "snapshot"
+}
+
+// FindMessage implements DescriptorSource
+func (s *SnapshotSource) FindMessage(schemaName, version string) (*ResolvedMessage, error) {
+	bundle, err := s.bundle(version)
+	if err != nil {
+		logger.Debug("Snapshots cannot serve version %s of %s", version, schemaName)
+		return nil, err
+	}
+
+	md, err := findMessageInFiles(bundle.files, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("Found message descriptor in snapshot: %s (commit %s)", schemaName, bundle.manifest.Commit)
+	return &ResolvedMessage{
+		Descriptor:   md,
+		Files:        bundle.files,
+		Source:       s.Name(),
+		Commit:       bundle.manifest.Commit,
+		Digest:       bundle.digest(md),
+		ModuleDigest: bundle.manifest.ModuleDigest,
+	}, nil
+}
+
+// GetSchema implements SchemaSource
+func (s *SnapshotSource) GetSchema(messageName, version string, variant SchemaVariant) (*ResolvedSchema, error) {
+	bundle, err := s.bundle(version)
+	if err != nil {
+		logger.Debug("Snapshots cannot serve version %s of %s", version, messageName)
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(bundle.path, snapshotSchemaDir, variant.FileName(messageName)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("schema for %s %w in snapshot %s", messageName, ErrNotFound, bundle.manifest.Commit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot schema: %w", err)
+	}
+	return &ResolvedSchema{Data: data, Source: s.Name(), Commit: bundle.manifest.Commit, ModuleDigest: bundle.manifest.ModuleDigest}, nil
+}
+
+// RangeFiles implements FileLister, every bundle is listed under its commit
+func (s *SnapshotSource) RangeFiles(f func(version string, files *protoregistry.Files) bool) {
+	for _, bundle := range s.bundles {
+		if !f(bundle.manifest.Commit, bundle.files) {
+			return
+		}
+	}
+}
+
+// bundle returns the bundle serving version, see SnapshotSource
+func (s *SnapshotSource) bundle(version string) (*snapshotBundle, error) {
+	label := version
+	if label == "" {
+		label = "main"
+	}
+	for _, bundle := range s.bundles {
+		if bundle.manifest.Commit == version || bundle.manifest.Version == label {
+			return bundle, nil
+		}
+	}
+	if label == "main" {
+		return s.bundles[0], nil
+	}
+	return nil, fmt.Errorf("%w: commit %s cannot be served by the %s source, snapshot it first", ErrVersionUnavailable, version, s.Name())
+}
+
+// digest returns the memoized descriptorDigest of md
+func (b *snapshotBundle) digest(md protoreflect.MessageDescriptor) string {
+	if digest, ok := b.digests.Load(md.FullName()); ok {
+		return digest.(string)
+	}
+	digest := descriptorDigest(md)
+	b.digests.Store(md.FullName(), digest)
+	return digest
+}
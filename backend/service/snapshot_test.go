@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// newFakeBSRSnapshot snapshots proto.Task from bsr into dir at version
+func newFakeBSRSnapshot(t *testing.T, bsr *fakeBSR, version, dir string) (*SnapshotManifest, string) {
+	t.Helper()
+	descriptors := NewBSRDescriptorSource("org", "module", "")
+	descriptors.httpClient.Transport = bsr
+	manifest, bundle, err := CreateSnapshot(descriptors, newFakeBSRSchemaSource(bsr), version, dir)
+	if err != nil {
+		t.Fatalf("CreateSnapshot failed: %v", err)
+	}
+	return manifest, bundle
+}
+
+func TestSnapshotSource(t *testing.T) {
+	bsr := &fakeBSR{labelCommit: testCommitID, image: imageOf(t, protoregistry.GlobalFiles, "proto.Task"), requests: make(map[string]int)}
+	dir := t.TempDir()
+	manifest, bundle := newFakeBSRSnapshot(t, bsr, "main", dir)
+
+	if bundle != filepath.Join(dir, testCommitID) || manifest.Commit != testCommitID || manifest.ModuleDigest != "b5:deadbeef" {
+		t.Errorf("Expected a bundle of commit %s with its module digest, got %s %+v", testCommitID, bundle, manifest)
+	}
+	// Every variant of every message of the module is downloaded, nothing of buf/validate
+	if _, err := os.Stat(filepath.Join(bundle, snapshotSchemaDir, "proto.Task.jsonschema.strict.bundle.json")); err != nil {
+		t.Errorf("Expected the strict bundled schema of proto.Task: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(bundle, snapshotSchemaDir, "buf.validate.FieldRules.schema.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected no schemas of buf/validate, got %v", err)
+	}
+	if manifest.Schemas == 0 || manifest.Schemas%len(allSchemaVariants) != 0 {
+		t.Errorf("Expected all %d variants of each message, got %d schema(s)", len(allSchemaVariants), manifest.Schemas)
+	}
+
+	source, err := NewSnapshotSource(dir)
+	if err != nil {
+		t.Fatalf("NewSnapshotSource failed: %v", err)
+	}
+	for _, version := range []string{"", "main", testCommitID} {
+		resolved, err := source.FindMessage("proto.Task", version)
+		if err != nil {
+			t.Fatalf("Version %q: FindMessage failed: %v", version, err)
+		}
+		if resolved.Source != "snapshot" || resolved.Commit != testCommitID || resolved.ModuleDigest != "b5:deadbeef" || resolved.Digest == "" {
+			t.Errorf("Version %q: expected proto.Task of the snapshot, got %+v", version, resolved)
+		}
+		schema, err := source.GetSchema("proto.Task", version, SchemaVariant{JSONNames: true, Strict: true})
+		if err != nil {
+			t.Fatalf("Version %q: GetSchema failed: %v", version, err)
+		}
+		if string(schema.Data) != `{"type":"object"}` || schema.Commit != testCommitID {
+			t.Errorf("Version %q: expected the downloaded schema, got %s at commit %q", version, schema.Data, schema.Commit)
+		}
+	}
+
+	if _, err := source.FindMessage("proto.Task", "v2"); !errors.Is(err, ErrVersionUnavailable) {
+		t.Errorf("Expected ErrVersionUnavailable for a version that was not snapshotted, got %v", err)
+	}
+	if _, err := source.GetSchema("proto.Missing", "main", SchemaVariant{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a schema the snapshot does not have, got %v", err)
+	}
+}
+
+func TestSnapshotServesDefaultVersionFromCommitBundle(t *testing.T) {
+	bsr := &fakeBSR{labelCommit: testCommitID, image: imageOf(t, protoregistry.GlobalFiles, "proto.Task"), requests: make(map[string]int)}
+	dir := t.TempDir()
+	newFakeBSRSnapshot(t, bsr, testCommitID, dir)
+	source, err := NewSnapshotSource(dir)
+	if err != nil {
+		t.Fatalf("NewSnapshotSource failed: %v", err)
+	}
+
+	// The services request "main" when no version is given; without a bundle snapshotted
+	// for main, the most recent bundle serves it
+	validator, err := protovalidate.New()
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+	resolved, err := NewValidationService(validator, source, false).ResolveMessageDescriptor("proto.Task", "")
+	if err != nil || resolved.Commit != testCommitID {
+		t.Fatalf("Expected proto.Task of commit %s, got %v, %v", testCommitID, resolved, err)
+	}
+	schema, err := NewSchemaService(source).GetSchema("proto.Task", "", SchemaOptions{})
+	if err != nil || schema.Commit != testCommitID {
+		t.Fatalf("Expected the schema of commit %s, got %v, %v", testCommitID, schema, err)
+	}
+
+	if _, err := NewSchemaService(source).GetSchema("proto.Task", "v2", SchemaOptions{}); !errors.Is(err, ErrVersionUnavailable) {
+		t.Errorf("Expected ErrVersionUnavailable for another label, got %v", err)
+	}
+}
+
+func TestSnapshotFailsWithoutBSR(t *testing.T) {
+	bsr := &fakeBSR{labelCommit: testCommitID, requests: make(map[string]int)}
+	descriptors := NewBSRDescriptorSource("org", "module", "")
+	descriptors.httpClient.Transport = bsr
+	dir := t.TempDir()
+
+	if _, _, err := CreateSnapshot(descriptors, newFakeBSRSchemaSource(bsr), "main", dir); err == nil {
+		t.Fatal("Expected CreateSnapshot to fail when the Reflection API fails")
+	}
+	if _, err := NewSnapshotSource(dir); err == nil {
+		t.Error("Expected no bundle to be left behind")
+	}
+}
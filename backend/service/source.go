@@ -0,0 +1,331 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"validation-service/backend/config"
+	"validation-service/backend/logger"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ErrNotFound is wrapped by sources that do not know the requested message or schema
+// Chains fall through to the next source on it, any other error is a failure of the source
+var ErrNotFound = errors.New("not found")
+
+// ErrVersionUnavailable is wrapped by sources that cannot serve the requested version at all,
+// e.g. a specific commit asked of the compiled-in registry
+// Chains fall through on it like on ErrNotFound
+var ErrVersionUnavailable = errors.New("version not available")
+
+// ErrSourceUnavailable is wrapped by the services when a source fails for any reason other
+// than not knowing the message or version, e.g. BSR is unreachable
+var ErrSourceUnavailable = errors.New("source unavailable")
+
+// Resolution describes where a descriptor or schema came from, it is reported in responses
+// so callers learn which definitions were actually used
+type Resolution struct {
+	Commit       string `json:"resolvedCommit,omitempty"` // Concrete commit the requested version resolved to, LocalVersion for local sources
+	Source       string `json:"source,omitempty"`         // Source that satisfied the lookup, e.g. "local", "bsr", "cache", "generated", "snapshot" or "registered"
+	Digest       string `json:"digest,omitempty"`         // sha256 of the definitions used, computed by this service, see descriptorDigest and contentDigest
+	ModuleDigest string `json:"moduleDigest,omitempty"`   // BSR module digest of Commit as in buf.lock, e.g. "b5:...", empty for local sources
+	Stale        bool   `json:"stale,omitempty"`          // Served from the persistent cache because BSR failed, Commit is the one the label last resolved to
+}
+
+// ResolvedMessage is a message descriptor and the registry it was resolved in
+type ResolvedMessage struct {
+	Descriptor   protoreflect.MessageDescriptor
+	Files        *protoregistry.Files // Registry holding the descriptor and its dependencies
+	Source       string               // Name of the source that resolved the message
+	Commit       string               // Concrete commit the version resolved to, "" if unknown
+	Digest       string               // sha256 of the message's file and its imports, see descriptorDigest
+	ModuleDigest string               // BSR module digest of Commit, "" if unknown
+	Stale        bool                 // Last good copy served because the source failed, see PersistentCache
+}
+
+// Resolution returns where the message was resolved
+func (r *ResolvedMessage) Resolution() Resolution {
+	return Resolution{Commit: r.Commit, Source: r.Source, Digest: r.Digest, ModuleDigest: r.ModuleDigest, Stale: r.Stale}
+}
+
+// Types resolves Any and extension types against the registry the message was resolved in,
+// so decoding a payload never mixes in types from another source or commit
+func (r *ResolvedMessage) Types() *dynamicpb.Types {
+	return dynamicpb.NewTypes(r.Files)
+}
+
+// DescriptorSource resolves message descriptors, e.g. from the compiled-in registry or BSR
+type DescriptorSource interface {
+	// Name identifies the source in logs and errors
+	Name() string
+
+	// FindMessage resolves the message named schemaName at version (a commit ID or label)
+	// Returns an error wrapping ErrNotFound if the source does not know the message
+	FindMessage(schemaName, version string) (*ResolvedMessage, error)
+}
+
+// ResolvedSchema is a JSON Schema document and where it came from
+type ResolvedSchema struct {
+	Data         []byte
+	Source       string // Name of the source that returned the schema
+	Commit       string // Concrete commit the schema was generated from, "" if unknown
+	Digest       string // sha256 of Data, set by SchemaService
+	ModuleDigest string // BSR module digest of Commit, "" if unknown
+	Stale        bool   // Last good copy served because the source failed, see PersistentCache
+}
+
+// Resolution returns where the schema was retrieved
+func (r *ResolvedSchema) Resolution() Resolution {
+	return Resolution{Commit: r.Commit, Source: r.Source, Digest: r.Digest, ModuleDigest: r.ModuleDigest, Stale: r.Stale}
+}
+
+// SchemaSource retrieves JSON Schema documents, e.g. from gen/jsonschema or BSR
+type SchemaSource interface {
+	// Name identifies the source in logs and errors
+	Name() string
+
+	// GetSchema returns the variant of the JSON Schema for the message named messageName
+	// at version (a commit ID or label, "" for the default)
+	// Returns an error wrapping ErrNotFound if the source does not have the schema, or
+	// ErrVersionUnavailable if it cannot serve version
+	GetSchema(messageName, version string, variant SchemaVariant) (*ResolvedSchema, error)
+}
+
+// ChainDescriptorSource tries its sources in order and returns the first match
+type ChainDescriptorSource struct {
+	sources []DescriptorSource
+}
+
+// NewChainDescriptorSource creates a source that falls back through sources in order
+func NewChainDescriptorSource(sources ...DescriptorSource) *ChainDescriptorSource {
+	return &ChainDescriptorSource{sources: sources}
+}
+
+// Name implements DescriptorSource
+func (c *ChainDescriptorSource) Name() string {
+	return chainName(c.sources)
+}
+
+// FindMessage implements DescriptorSource
+func (c *ChainDescriptorSource) FindMessage(schemaName, version string) (*ResolvedMessage, error) {
+	return resolveChain(c.sources, schemaName, func(source DescriptorSource) (*ResolvedMessage, error) {
+		return source.FindMessage(schemaName, version)
+	})
+}
+
+// CacheStats reports the counters of the first cached source in the chain
+func (c *ChainDescriptorSource) CacheStats() DescriptorCacheStats {
+	for _, source := range c.sources {
+		if cached, ok := source.(cacheStatsReporter); ok {
+			return cached.CacheStats()
+		}
+	}
+	return DescriptorCacheStats{}
+}
+
+// ChainSchemaSource tries its sources in order and returns the first match
+type ChainSchemaSource struct {
+	sources []SchemaSource
+}
+
+// NewChainSchemaSource creates a source that falls back through sources in order
+func NewChainSchemaSource(sources ...SchemaSource) *ChainSchemaSource {
+	return &ChainSchemaSource{sources: sources}
+}
+
+// Name implements SchemaSource
+func (c *ChainSchemaSource) Name() string {
+	return chainName(c.sources)
+}
+
+// GetSchema implements SchemaSource
+func (c *ChainSchemaSource) GetSchema(messageName, version string, variant SchemaVariant) (*ResolvedSchema, error) {
+	return resolveChain(c.sources, variant.FileName(messageName), func(source SchemaSource) (*ResolvedSchema, error) {
+		return source.GetSchema(messageName, version, variant)
+	})
+}
+
+// DescriptorSources are the descriptor sources the modes combine
+// A source no configured mode uses may be nil
+type DescriptorSources struct {
+	Local      DescriptorSource // Compiled-in types
+	BSR        DescriptorSource // BSR Reflection API
+	Image      DescriptorSource // FileDescriptorSet images on disk, see ImageDescriptorSource
+	Snapshot   DescriptorSource // Offline snapshots of the BSR module, see SnapshotSource
+	Registered DescriptorSource // Sets registered at runtime, see DescriptorRegistry
+}
+
+// SchemaSources are the sources of JSON Schema files the modes combine
+// A source no configured mode uses may be nil
+type SchemaSources struct {
+	Local      SchemaSource // Files generated by protoschema-jsonschema, e.g. gen/jsonschema
+	BSR        SchemaSource // Generated archive of the BSR module
+	Snapshot   SchemaSource // Generated archives in the offline snapshots, see SnapshotSource
+	Registered SchemaSource // Schemas generated from the sets registered at runtime
+}
+
+// NewDescriptorSourceForMode combines the descriptor sources as mode requires:
+// - BSROnly: only BSR
+// - LocalOnly: only Local
+// - LocalThenBSR: Local first, then BSR
+// - ImageOnly: only Image
+// - Snapshot: only Snapshot
+// Registered, if not nil, comes first in every mode: it only serves its own, prefixed versions
+func NewDescriptorSourceForMode(mode config.SchemaSourceMode, sources DescriptorSources) DescriptorSource {
+	var source DescriptorSource
+	switch mode {
+	case config.BSROnly:
+		source = sources.BSR
+	case config.LocalOnly:
+		source = sources.Local
+	case config.ImageOnly:
+		source = sources.Image
+	case config.Snapshot:
+		source = sources.Snapshot
+	default:
+		source = NewChainDescriptorSource(sources.Local, sources.BSR)
+	}
+	if sources.Registered == nil {
+		return source
+	}
+	return NewChainDescriptorSource(sources.Registered, source)
+}
+
+// NewSchemaSourceForMode combines the schema sources as mode requires, with the same
+// semantics as NewDescriptorSourceForMode, followed by generated (if not nil) for the
+// schemas the files do not have, see GeneratedSchemaSource
+// ImageOnly has no schema files, all of its schemas are generated
+func NewSchemaSourceForMode(mode config.SchemaSourceMode, sources SchemaSources, generated SchemaSource) SchemaSource {
+	var chain []SchemaSource
+	if sources.Registered != nil {
+		chain = append(chain, sources.Registered)
+	}
+	switch mode {
+	case config.BSROnly:
+		chain = append(chain, sources.BSR)
+	case config.LocalOnly:
+		chain = append(chain, sources.Local)
+	case config.ImageOnly:
+	case config.Snapshot:
+		chain = append(chain, sources.Snapshot)
+	default:
+		chain = append(chain, sources.Local, sources.BSR)
+	}
+	if generated != nil {
+		chain = append(chain, generated)
+	}
+	if len(chain) == 1 {
+		return chain[0]
+	}
+	return NewChainSchemaSource(chain...)
+}
+
+// cacheStatsReporter is implemented by sources that cache what they resolve
+type cacheStatsReporter interface {
+	CacheStats() DescriptorCacheStats
+}
+
+// namedSource is the part shared by DescriptorSource and SchemaSource
+type namedSource interface {
+	Name() string
+}
+
+// chainName joins the names of sources, e.g. "local>bsr"
+func chainName[S namedSource](sources []S) string {
+	names := make([]string, 0, len(sources))
+	for _, source := range sources {
+		names = append(names, source.Name())
+	}
+	return strings.Join(names, ">")
+}
+
+// resolveChain calls lookup for each source in order and returns the first success
+// Sources that do not know name, or cannot serve the version, are skipped; if a source
+// fails for another reason and no later source succeeds, that failure is returned rather
+// than ErrNotFound, so an unreachable source is not reported as a missing schema
+// If no source knows the version at all, the ErrVersionUnavailable error is returned
+func resolveChain[S namedSource, T any](sources []S, name string, lookup func(S) (T, error)) (T, error) {
+	var zero T
+	var failure, unavailable error
+	notFound := false
+	for _, source := range sources {
+		result, err := lookup(source)
+		if err == nil {
+			return result, nil
+		}
+		switch {
+		case errors.Is(err, ErrNotFound):
+			logger.Debug("Source %s does not have %s, trying next source", source.Name(), name)
+			notFound = true
+		case errors.Is(err, ErrVersionUnavailable):
+			logger.Debug("Source %s cannot serve the version of %s, trying next source", source.Name(), name)
+			unavailable = err
+		default:
+			logger.Debug("Source %s failed for %s: %v", source.Name(), name, err)
+			failure = err
+		}
+	}
+	if failure != nil {
+		return zero, failure
+	}
+	if unavailable != nil && !notFound {
+		return zero, unavailable
+	}
+	return zero, fmt.Errorf("%s %w in %s", name, ErrNotFound, chainName(sources))
+}
+
+// descriptorDigest returns a digest of the file defining md and all of its imports, so two
+// sources serving the same definitions report the same digest
+// Source code info (comments, spans) is not part of the digest
+// It is not the BSR module digest, which covers the whole module and is reported as
+// ModuleDigest by the BSR sources
+func descriptorDigest(md protoreflect.MessageDescriptor) string {
+	files := make(map[string]protoreflect.FileDescriptor)
+	collectFileClosure(md.ParentFile(), files)
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	hash := sha256.New()
+	marshalOpts := proto.MarshalOptions{Deterministic: true}
+	for _, path := range paths {
+		fdp := protodesc.ToFileDescriptorProto(files[path])
+		fdp.SourceCodeInfo = nil
+		data, err := marshalOpts.Marshal(fdp)
+		if err != nil {
+			logger.Warn("Failed to marshal %s for digest: %v", path, err)
+			return ""
+		}
+		hash.Write(data)
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// collectFileClosure adds fd and its transitive imports to files, keyed by path
+func collectFileClosure(fd protoreflect.FileDescriptor, files map[string]protoreflect.FileDescriptor) {
+	if _, ok := files[fd.Path()]; ok {
+		return
+	}
+	files[fd.Path()] = fd
+	imports := fd.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		collectFileClosure(imports.Get(i).FileDescriptor, files)
+	}
+}
+
+// contentDigest returns the digest of a document, in the same format as descriptorDigest
+func contentDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,399 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"validation-service/backend/config"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// fakeDescriptorSource resolves from the compiled-in registry or returns err, counting calls
+type fakeDescriptorSource struct {
+	name  string
+	err   error
+	calls int
+}
+
+func (s *fakeDescriptorSource) Name() string {
+	return s.name
+}
+
+func (s *fakeDescriptorSource) FindMessage(schemaName, version string) (*ResolvedMessage, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	resolved, err := NewRegistryDescriptorSource(nil).FindMessage(schemaName, version)
+	if err != nil {
+		return nil, err
+	}
+	resolved.Source = s.name
+	resolved.Commit = testCommitID
+	return resolved, nil
+}
+
+// fakeSchemaSource fails every lookup with err
+type fakeSchemaSource struct {
+	name string
+	err  error
+}
+
+func (s *fakeSchemaSource) Name() string {
+	return s.name
+}
+
+func (s *fakeSchemaSource) GetSchema(messageName, version string, variant SchemaVariant) (*ResolvedSchema, error) {
+	return nil, s.err
+}
+
+func TestChainDescriptorSourceFallsThroughNotFound(t *testing.T) {
+	missing := &fakeDescriptorSource{name: "missing", err: ErrNotFound}
+	found := &fakeDescriptorSource{name: "found"}
+	chain := NewChainDescriptorSource(missing, found)
+
+	resolved, err := chain.FindMessage("proto.Task", "main")
+	if err != nil {
+		t.Fatalf("FindMessage failed: %v", err)
+	}
+	if resolved.Source != "found" {
+		t.Errorf("Expected source found, got %s", resolved.Source)
+	}
+	if chain.Name() != "missing>found" {
+		t.Errorf("Unexpected chain name: %s", chain.Name())
+	}
+}
+
+func TestChainDescriptorSourceReportsFailure(t *testing.T) {
+	unreachable := errors.New("connection refused")
+	chain := NewChainDescriptorSource(
+		&fakeDescriptorSource{name: "local", err: ErrNotFound},
+		&fakeDescriptorSource{name: "bsr", err: unreachable},
+	)
+
+	_, err := chain.FindMessage("proto.Task", "main")
+	if !errors.Is(err, unreachable) {
+		t.Errorf("Expected source failure, got %v", err)
+	}
+
+	chain = NewChainDescriptorSource(&fakeDescriptorSource{name: "local", err: ErrNotFound})
+	_, err = chain.FindMessage("proto.Task", "main")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestNewDescriptorSourceForMode(t *testing.T) {
+	local := &fakeDescriptorSource{name: "local"}
+	bsr := &fakeDescriptorSource{name: "bsr"}
+
+	image := &fakeDescriptorSource{name: "image"}
+
+	tests := map[config.SchemaSourceMode]string{
+		config.BSROnly:      "bsr",
+		config.LocalOnly:    "local",
+		config.LocalThenBSR: "local>bsr",
+		config.ImageOnly:    "image",
+	}
+	for mode, want := range tests {
+		if got := NewDescriptorSourceForMode(mode, DescriptorSources{Local: local, BSR: bsr, Image: image}).Name(); got != want {
+			t.Errorf("Mode %d: expected %s, got %s", mode, want, got)
+		}
+	}
+}
+
+func TestNewSchemaSourceForModeGeneratesMissingSchemas(t *testing.T) {
+	sources := SchemaSources{Local: NewLocalSchemaSource(t.TempDir()), BSR: &fakeSchemaSource{name: "bsr", err: ErrNotFound}}
+	generated := NewGeneratedSchemaSource(NewRegistryDescriptorSource(nil))
+
+	tests := map[config.SchemaSourceMode]string{
+		config.BSROnly:      "bsr>generated",
+		config.LocalOnly:    "local>generated",
+		config.LocalThenBSR: "local>bsr>generated",
+		config.ImageOnly:    "generated",
+	}
+	for mode, want := range tests {
+		source := NewSchemaSourceForMode(mode, sources, generated)
+		if got := source.Name(); got != want {
+			t.Errorf("Mode %d: expected %s, got %s", mode, want, got)
+		}
+		if schema, err := source.GetSchema("proto.Task", "main", SchemaVariant{}); err != nil || schema.Source != "generated" {
+			t.Errorf("Mode %d: expected a generated schema, got %v", mode, err)
+		}
+	}
+}
+
+func TestCachedDescriptorSourceServesRepeatedLookups(t *testing.T) {
+	source := &fakeDescriptorSource{name: "bsr"}
+	cached := NewCachedDescriptorSource(source, NewDescriptorCache(time.Minute, 10), "buf.build/org/module")
+
+	for i := 0; i < 2; i++ {
+		resolved, err := cached.FindMessage("proto.Task", "main")
+		if err != nil {
+			t.Fatalf("FindMessage failed: %v", err)
+		}
+		if resolved.Descriptor.FullName() != "proto.Task" {
+			t.Errorf("Unexpected descriptor: %s", resolved.Descriptor.FullName())
+		}
+	}
+	if source.calls != 1 {
+		t.Errorf("Expected 1 call to the wrapped source, got %d", source.calls)
+	}
+
+	// The resolved commit is cached too
+	if _, err := cached.FindMessage("proto.Task", testCommitID); err != nil {
+		t.Fatalf("FindMessage by commit failed: %v", err)
+	}
+	if source.calls != 1 {
+		t.Errorf("Expected commit lookup to be served from cache, got %d calls", source.calls)
+	}
+	if stats := cached.CacheStats(); stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestLocalSchemaSource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "proto.Task.schema.bundle.json"), []byte(`{"type":"object"}`), 0o644); err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+	source := NewLocalSchemaSource(dir)
+
+	for _, version := range []string{"", "main", LocalVersion} {
+		data, err := source.GetSchema("proto.Task", version, SchemaVariant{})
+		if err != nil {
+			t.Fatalf("GetSchema(%q) failed: %v", version, err)
+		}
+		if string(data.Data) != `{"type":"object"}` || data.Source != "local" || data.Commit != LocalVersion {
+			t.Errorf("Unexpected schema: %+v", data)
+		}
+	}
+
+	if _, err := source.GetSchema("proto.Missing", "", SchemaVariant{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if _, err := source.GetSchema("proto.Task", testCommitID, SchemaVariant{}); !errors.Is(err, ErrVersionUnavailable) {
+		t.Errorf("Expected ErrVersionUnavailable, got %v", err)
+	}
+}
+
+func TestChainSchemaSourceSkipsLocalForPinnedCommit(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "proto.Task.schema.bundle.json"), []byte(`{}`), 0o644); err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+	chain := NewChainSchemaSource(NewLocalSchemaSource(dir), NewLocalSchemaSource(t.TempDir()))
+
+	// No source has the commit: the version error is reported, not a missing schema
+	if _, err := chain.GetSchema("proto.Task", testCommitID, SchemaVariant{}); !errors.Is(err, ErrVersionUnavailable) {
+		t.Errorf("Expected ErrVersionUnavailable, got %v", err)
+	}
+	// No source has the schema at the default version: the schema is missing
+	if _, err := chain.GetSchema("proto.Missing", "", SchemaVariant{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestBSRSchemaSourceURLPinsVersion(t *testing.T) {
+	source := NewBSRSchemaSource("org", "module", "", 0)
+	base := "https://buf.build/gen/archive/org/module/bufbuild/protoschema-jsonschema/raw/"
+
+	tests := map[string]string{
+		"":           base + "latest/proto.Task.schema.bundle.json",
+		"main":       base + "latest/proto.Task.schema.bundle.json",
+		testCommitID: base + testCommitID + "/proto.Task.schema.bundle.json",
+		"v1.2.0":     base + "v1.2.0/proto.Task.schema.bundle.json",
+	}
+	for version, want := range tests {
+		if got := source.buildBSRURL("proto.Task.schema.bundle.json", version); got != want {
+			t.Errorf("Version %q: expected %s, got %s", version, want, got)
+		}
+	}
+}
+
+// fakeBSR answers the BSR requests of the schema source from memory, counting requests per path
+type fakeBSR struct {
+	labelCommit string                          // Commit every label resolves to, "" makes label lookups fail
+	image       *descriptorpb.FileDescriptorSet // Set the Reflection API returns, nil makes it fail
+	unavailable bool                            // Fail every request, as if BSR were down
+	requests    map[string]int
+}
+
+func (f *fakeBSR) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.requests[req.URL.Path]++
+	respond := func(status int, body string) (*http.Response, error) {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header), Request: req}, nil
+	}
+
+	switch {
+	case f.unavailable:
+		return respond(http.StatusServiceUnavailable, "")
+	case strings.HasSuffix(req.URL.Path, "LabelService/ListLabelHistory"):
+		if f.labelCommit == "" {
+			return respond(http.StatusServiceUnavailable, "")
+		}
+		return respond(http.StatusOK, `{"values":[{"commit":{"id":"`+f.labelCommit+`"}}]}`)
+	case strings.HasSuffix(req.URL.Path, "CommitService/GetCommits"):
+		return respond(http.StatusOK, `{"commits":[{"id":"`+testCommitID+`","digest":{"type":"DIGEST_TYPE_B5","value":"3q2+7w=="}}]}`)
+	case strings.HasSuffix(req.URL.Path, "FileDescriptorSetService/GetFileDescriptorSet"):
+		if f.image == nil {
+			return respond(http.StatusServiceUnavailable, "")
+		}
+		image, err := protojson.Marshal(f.image)
+		if err != nil {
+			return nil, err
+		}
+		return respond(http.StatusOK, `{"fileDescriptorSet":`+string(image)+`,"version":"`+testCommitID+`"}`)
+	case strings.Contains(req.URL.Path, "/protoschema-jsonschema/raw/"):
+		return respond(http.StatusOK, `{"type":"object"}`)
+	default:
+		return respond(http.StatusNotFound, "")
+	}
+}
+
+// newFakeBSRSchemaSource returns a BSRSchemaSource talking to bsr instead of buf.build
+func newFakeBSRSchemaSource(bsr *fakeBSR) *BSRSchemaSource {
+	source := NewBSRSchemaSource("org", "module", "", 0)
+	source.httpClient.Transport = bsr
+	return source
+}
+
+func TestBSRSchemaSourceResolvesLabelsToCommits(t *testing.T) {
+	bsr := &fakeBSR{labelCommit: testCommitID, requests: make(map[string]int)}
+	source := newFakeBSRSchemaSource(bsr)
+
+	for _, version := range []string{"", "main", testCommitID} {
+		schema, err := source.GetSchema("proto.Task", version, SchemaVariant{})
+		if err != nil {
+			t.Fatalf("Version %q: GetSchema failed: %v", version, err)
+		}
+		if schema.Commit != testCommitID {
+			t.Errorf("Version %q: expected commit %s, got %q", version, testCommitID, schema.Commit)
+		}
+		if schema.ModuleDigest != "b5:deadbeef" {
+			t.Errorf("Version %q: expected module digest b5:deadbeef, got %q", version, schema.ModuleDigest)
+		}
+	}
+
+	// Every fetch used the archive of the resolved commit, never "latest"
+	archivePath := "/gen/archive/org/module/bufbuild/protoschema-jsonschema/raw/" + testCommitID + "/proto.Task.schema.bundle.json"
+	if bsr.requests[archivePath] == 0 {
+		t.Errorf("Expected the archive of the resolved commit to be fetched, got requests %v", bsr.requests)
+	}
+	// Module digests of a commit are looked up once
+	if got := bsr.requests["/buf.registry.module.v1beta1.CommitService/GetCommits"]; got != 1 {
+		t.Errorf("Expected 1 module digest lookup, got %d", got)
+	}
+}
+
+func TestBSRSchemaSourceMemoizesLabels(t *testing.T) {
+	bsr := &fakeBSR{labelCommit: testCommitID, requests: make(map[string]int)}
+	source := newFakeBSRSchemaSource(bsr)
+	source.labelTTL = time.Minute
+	now := time.Now()
+	source.now = func() time.Time { return now }
+
+	const labelHistory = "/buf.registry.module.v1beta1.LabelService/ListLabelHistory"
+	for _, version := range []string{"", "main", "main"} {
+		if schema, err := source.GetSchema("proto.Task", version, SchemaVariant{}); err != nil || schema.Commit != testCommitID {
+			t.Fatalf("Version %q: expected commit %s, got %v, %v", version, testCommitID, schema, err)
+		}
+	}
+	if got := bsr.requests[labelHistory]; got != 1 {
+		t.Errorf("Expected the label to be resolved once within the TTL, got %d lookups", got)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := source.GetSchema("proto.Task", "main", SchemaVariant{}); err != nil {
+		t.Fatalf("GetSchema failed: %v", err)
+	}
+	if got := bsr.requests[labelHistory]; got != 2 {
+		t.Errorf("Expected the label to be resolved again once expired, got %d lookups", got)
+	}
+}
+
+func TestBSRSchemaSourceReportsUnresolvedLabels(t *testing.T) {
+	bsr := &fakeBSR{requests: make(map[string]int)}
+	source := newFakeBSRSchemaSource(bsr)
+
+	schema, err := source.GetSchema("proto.Task", "main", SchemaVariant{})
+	if err != nil {
+		t.Fatalf("GetSchema failed: %v", err)
+	}
+	if schema.Commit != "" || schema.ModuleDigest != "" {
+		t.Errorf("Expected an unresolved commit, got commit=%q, moduleDigest=%q", schema.Commit, schema.ModuleDigest)
+	}
+	if bsr.requests["/gen/archive/org/module/bufbuild/protoschema-jsonschema/raw/latest/proto.Task.schema.bundle.json"] != 1 {
+		t.Errorf("Expected the latest archive to be fetched, got requests %v", bsr.requests)
+	}
+}
+
+// isolatedTaskFiles builds a registry holding a proto.Task unrelated to the compiled-in one,
+// as a BSR commit with a different definition would
+func isolatedTaskFiles(t *testing.T) *protoregistry.Files {
+	t.Helper()
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("isolated/task.proto"),
+		Package: proto.String("proto"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Task"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("extra"),
+				JsonName: proto.String("extra"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			}},
+		}},
+	}
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{fdp}})
+	if err != nil {
+		t.Fatalf("Failed to build registry: %v", err)
+	}
+	return files
+}
+
+func TestRegistryDescriptorSourceDoesNotFallBackToGlobalFiles(t *testing.T) {
+	source := NewRegistryDescriptorSource(&protoregistry.Files{})
+	if _, err := source.FindMessage("proto.Task", "main"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound from an empty registry, got %v", err)
+	}
+}
+
+func TestValidationServiceUsesResolvedRegistryOnly(t *testing.T) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+	svc := NewValidationService(validator, NewRegistryDescriptorSource(isolatedTaskFiles(t)), true)
+
+	resolved, err := svc.ResolveMessageDescriptor("proto.Task", "main")
+	if err != nil {
+		t.Fatalf("ResolveMessageDescriptor failed: %v", err)
+	}
+	if resolved.Descriptor.ParentFile().Path() != "isolated/task.proto" {
+		t.Errorf("Expected isolated descriptor, got %s", resolved.Descriptor.ParentFile().Path())
+	}
+	if resolved.Source != "local" {
+		t.Errorf("Expected source local, got %s", resolved.Source)
+	}
+
+	// Valid against the isolated definition, unknown fields against the compiled-in one
+	success, errs, err := svc.ValidateProto("proto.Task", []byte(`{"extra": "x"}`), "")
+	if err != nil || !success {
+		t.Errorf("Expected payload to validate against the isolated definition: %v %+v", err, errs)
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"validation-service/backend/logger"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "validation-service/backend/proto"
+	taskv1 "validation-service/backend/proto/task/v1"
+)
+
+// defaultTaskPageSize is used when a ListTasks request does not set a page size
+const defaultTaskPageSize = 50
+
+// TaskService implements task management on top of a TaskStore
+// Requests are expected to have been validated with protovalidate by the caller,
+// i.e. the gRPC interceptors or the HTTP task handler
+type TaskService struct {
+	store TaskStore
+}
+
+// NewTaskService creates a new task service backed by store
+func NewTaskService(store TaskStore) *TaskService {
+	logger.Debug("Initializing TaskService with store=%T", store)
+	return &TaskService{
+		store: store,
+	}
+}
+
+// CreateTask stores the task of req under a newly generated ID
+func (s *TaskService) CreateTask(req *taskv1.CreateTaskRequest) (*taskv1.TaskRecord, error) {
+	id, err := newTaskID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate task ID: %w", err)
+	}
+
+	now := timestamppb.Now()
+	record := &taskv1.TaskRecord{
+		Id:         id,
+		Task:       proto.Clone(req.GetTask()).(*pb.Task),
+		CreateTime: now,
+		UpdateTime: now,
+	}
+	if err := s.store.Create(record); err != nil {
+		return nil, fmt.Errorf("failed to store task: %w", err)
+	}
+
+	logger.Info("Created task id=%s, status=%s", id, record.GetTask().GetStatus())
+	return record, nil
+}
+
+// GetTask returns the task identified by req
+func (s *TaskService) GetTask(req *taskv1.GetTaskRequest) (*taskv1.TaskRecord, error) {
+	return s.store.Get(req.GetId())
+}
+
+// ListTasks returns a page of tasks
+// Page tokens are opaque to clients, they encode the offset of the next page
+func (s *TaskService) ListTasks(req *taskv1.ListTasksRequest) (*taskv1.ListTasksResponse, error) {
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultTaskPageSize
+	}
+
+	offset := 0
+	if req.GetPageToken() != "" {
+		parsed, err := strconv.Atoi(req.GetPageToken())
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("%w: invalid page token: %q", ErrInvalidArgument, req.GetPageToken())
+		}
+		offset = parsed
+	}
+
+	records, more, err := s.store.List(req.GetStatus(), offset, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	response := &taskv1.ListTasksResponse{Tasks: records}
+	if more {
+		response.NextPageToken = strconv.Itoa(offset + len(records))
+	}
+	logger.Debug("Listed %d task(s) with status=%s, offset=%d, more=%v", len(records), req.GetStatus(), offset, more)
+	return response, nil
+}
+
+// UpdateTaskStatus sets the status and comment of a task and refreshes its timestamps
+func (s *TaskService) UpdateTaskStatus(req *taskv1.UpdateTaskStatusRequest) (*taskv1.TaskRecord, error) {
+	update := req.GetUpdate()
+	now := timestamppb.Now()
+
+	record, err := s.store.Update(req.GetId(), func(record *taskv1.TaskRecord) {
+		record.Task.Status = update.GetStatus()
+		record.Task.Timestamp = now
+		record.Comment = update.GetComment()
+		record.UpdateTime = now
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Updated task id=%s to status=%s", req.GetId(), update.GetStatus())
+	return record, nil
+}
+
+// DeleteTask removes the task identified by req
+func (s *TaskService) DeleteTask(req *pb.DeleteTask) (*taskv1.TaskRecord, error) {
+	record, err := s.store.Delete(req.GetTaskId())
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Deleted task id=%s, comment=%q", req.GetTaskId(), req.GetComment())
+	return record, nil
+}
+
+// newTaskID returns a random 16 character hex ID
+func newTaskID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
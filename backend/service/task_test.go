@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "validation-service/backend/proto"
+	taskv1 "validation-service/backend/proto/task/v1"
+)
+
+func newTestTask(name string, status pb.TaskStatus) *taskv1.CreateTaskRequest {
+	return &taskv1.CreateTaskRequest{
+		Task: &pb.Task{Name: name, Timestamp: timestamppb.Now(), Status: status},
+	}
+}
+
+func TestTaskServiceLifecycle(t *testing.T) {
+	tasks := NewTaskService(NewMemoryTaskStore())
+
+	created, err := tasks.CreateTask(newTestTask("Write docs", pb.TaskStatus_TASK_STATUS_OPEN))
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	if created.GetId() == "" || created.GetCreateTime() == nil {
+		t.Fatalf("Expected ID and create time to be assigned, got %v", created)
+	}
+
+	comment := "waiting for review"
+	updated, err := tasks.UpdateTaskStatus(&taskv1.UpdateTaskStatusRequest{
+		Id:     created.GetId(),
+		Update: &pb.UpdateTask{Status: pb.TaskStatus_TASK_STATUS_BLOCKED, Comment: &comment},
+	})
+	if err != nil {
+		t.Fatalf("UpdateTaskStatus failed: %v", err)
+	}
+	if updated.GetTask().GetStatus() != pb.TaskStatus_TASK_STATUS_BLOCKED || updated.GetComment() != comment {
+		t.Errorf("Expected blocked task with comment, got %v", updated)
+	}
+
+	// Records returned by the store must not alias its contents
+	updated.Task.Name = "changed"
+	got, err := tasks.GetTask(&taskv1.GetTaskRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if got.GetTask().GetName() != "Write docs" {
+		t.Errorf("Expected stored task to be unaffected by caller changes, got %q", got.GetTask().GetName())
+	}
+
+	if _, err := tasks.DeleteTask(&pb.DeleteTask{TaskId: created.GetId()}); err != nil {
+		t.Fatalf("DeleteTask failed: %v", err)
+	}
+	if _, err := tasks.GetTask(&taskv1.GetTaskRequest{Id: created.GetId()}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a deleted task, got %v", err)
+	}
+}
+
+func TestTaskServiceListPaging(t *testing.T) {
+	tasks := NewTaskService(NewMemoryTaskStore())
+	for _, status := range []pb.TaskStatus{
+		pb.TaskStatus_TASK_STATUS_OPEN,
+		pb.TaskStatus_TASK_STATUS_COMPLETED,
+		pb.TaskStatus_TASK_STATUS_OPEN,
+		pb.TaskStatus_TASK_STATUS_OPEN,
+	} {
+		if _, err := tasks.CreateTask(newTestTask("Task", status)); err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+	}
+
+	var listed int
+	req := &taskv1.ListTasksRequest{PageSize: 2, Status: pb.TaskStatus_TASK_STATUS_OPEN}
+	for pages := 1; ; pages++ {
+		resp, err := tasks.ListTasks(req)
+		if err != nil {
+			t.Fatalf("ListTasks failed: %v", err)
+		}
+		for _, record := range resp.GetTasks() {
+			if record.GetTask().GetStatus() != pb.TaskStatus_TASK_STATUS_OPEN {
+				t.Errorf("Expected only open tasks, got %v", record)
+			}
+		}
+		listed += len(resp.GetTasks())
+		if resp.GetNextPageToken() == "" {
+			if pages != 2 {
+				t.Errorf("Expected 2 pages, got %d", pages)
+			}
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+	if listed != 3 {
+		t.Errorf("Expected 3 open tasks, got %d", listed)
+	}
+
+	if _, err := tasks.ListTasks(&taskv1.ListTasksRequest{PageToken: "not-a-token"}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument for an invalid page token, got %v", err)
+	}
+}
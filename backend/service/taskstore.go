@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"validation-service/backend/logger"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "validation-service/backend/proto"
+	taskv1 "validation-service/backend/proto/task/v1"
+)
+
+// ErrAlreadyExists is wrapped by stores asked to create a task whose ID is already stored
+var ErrAlreadyExists = errors.New("already exists")
+
+// TaskStore persists task records for the TaskService
+// Implementations must be safe for concurrent use and must not retain or return
+// records that callers can modify
+type TaskStore interface {
+	// Create stores a new record, its ID must not exist yet
+	// Returns an error wrapping ErrAlreadyExists if it does
+	Create(record *taskv1.TaskRecord) error
+
+	// Get returns the record with the given ID
+	// Get, Update and Delete return an error wrapping ErrNotFound for unknown IDs
+	Get(id string) (*taskv1.TaskRecord, error)
+
+	// List returns up to limit records in creation order, starting at offset
+	// If status is not TASK_STATUS_UNSPECIFIED only records with that status are considered
+	// more reports whether further records match after the returned page
+	List(status pb.TaskStatus, offset, limit int) (records []*taskv1.TaskRecord, more bool, err error)
+
+	// Update applies mutate to the record with the given ID atomically and returns the result
+	Update(id string, mutate func(record *taskv1.TaskRecord)) (*taskv1.TaskRecord, error)
+
+	// Delete removes the record with the given ID and returns it
+	Delete(id string) (*taskv1.TaskRecord, error)
+}
+
+// MemoryTaskStore is an in-memory TaskStore, its contents are lost when the process exits
+type MemoryTaskStore struct {
+	mu      sync.RWMutex
+	records map[string]*taskv1.TaskRecord
+	order   []string // IDs in creation order
+}
+
+// NewMemoryTaskStore creates an empty in-memory task store
+func NewMemoryTaskStore() *MemoryTaskStore {
+	logger.Debug("Initializing MemoryTaskStore")
+	return &MemoryTaskStore{
+		records: make(map[string]*taskv1.TaskRecord),
+	}
+}
+
+// Create implements TaskStore
+func (s *MemoryTaskStore) Create(record *taskv1.TaskRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.records[record.GetId()]; exists {
+		return fmt.Errorf("task %s %w", record.GetId(), ErrAlreadyExists)
+	}
+	s.records[record.GetId()] = proto.Clone(record).(*taskv1.TaskRecord)
+	s.order = append(s.order, record.GetId())
+	return nil
+}
+
+// Get implements TaskStore
+func (s *MemoryTaskStore) Get(id string) (*taskv1.TaskRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	record, ok := s.records[id]
+	if !ok {
+		return nil, taskNotFoundError(id)
+	}
+	return proto.Clone(record).(*taskv1.TaskRecord), nil
+}
+
+// List implements TaskStore
+func (s *MemoryTaskStore) List(status pb.TaskStatus, offset, limit int) ([]*taskv1.TaskRecord, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := make([]*taskv1.TaskRecord, 0, limit)
+	matched := 0
+	for _, id := range s.order {
+		record := s.records[id]
+		if status != pb.TaskStatus_TASK_STATUS_UNSPECIFIED && record.GetTask().GetStatus() != status {
+			continue
+		}
+		matched++
+		if matched <= offset {
+			continue
+		}
+		if len(records) == limit {
+			return records, true, nil
+		}
+		records = append(records, proto.Clone(record).(*taskv1.TaskRecord))
+	}
+	return records, false, nil
+}
+
+// Update implements TaskStore
+func (s *MemoryTaskStore) Update(id string, mutate func(record *taskv1.TaskRecord)) (*taskv1.TaskRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[id]
+	if !ok {
+		return nil, taskNotFoundError(id)
+	}
+	updated := proto.Clone(record).(*taskv1.TaskRecord)
+	mutate(updated)
+	s.records[id] = updated
+	return proto.Clone(updated).(*taskv1.TaskRecord), nil
+}
+
+// Delete implements TaskStore
+func (s *MemoryTaskStore) Delete(id string) (*taskv1.TaskRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[id]
+	if !ok {
+		return nil, taskNotFoundError(id)
+	}
+	delete(s.records, id)
+	for i, orderedID := range s.order {
+		if orderedID == id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	return record, nil
+}
+
+// taskNotFoundError is returned by stores for unknown task IDs
+func taskNotFoundError(id string) error {
+	return fmt.Errorf("task %s %w", id, ErrNotFound)
+}
@@ -1,301 +1,190 @@
 package service
 
 import (
-	"bytes"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"regexp"
 	"strings"
-	"validation-service/backend/config"
 	"validation-service/backend/logger"
 
 	"buf.build/go/protovalidate"
-	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/reflect/protoreflect"
-	"google.golang.org/protobuf/reflect/protoregistry"
-	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/dynamicpb"
 )
 
+// ErrInvalidArgument is wrapped by errors caused by the request itself, e.g. a payload that
+// cannot be decoded or a malformed message name, as opposed to failures of a source
+var ErrInvalidArgument = errors.New("invalid argument")
+
 // ValidationError represents a validation error with both friendly and technical messages
+// and, when derived from a protovalidate violation, the structured details of that violation
 type ValidationError struct {
-	Friendly  string `json:"friendly"`  // Human-readable message
-	Technical string `json:"technical"` // Original technical error
+	Friendly     string      `json:"friendly"`               // Human-readable message
+	Technical    string      `json:"technical"`              // Original technical error
+	Field        string      `json:"field,omitempty"`        // Field path using proto names, e.g. "contact_info.country_code"
+	JSONField    string      `json:"jsonField,omitempty"`    // Field path using JSON names, e.g. "contactInfo.countryCode"
+	Pointer      *string     `json:"pointer,omitempty"`      // RFC 6901 JSON Pointer into the payload, e.g. "/items/3/discount" ("" is the root), nil if the error has no location
+	RuleID       string      `json:"ruleId,omitempty"`       // Violated rule, e.g. "string.min_len"
+	ConstraintID string      `json:"constraintId,omitempty"` // Custom CEL constraint id, e.g. "comment_required_if_blocked"
+	Value        interface{} `json:"value,omitempty"`        // Offending value, if any
+	ForKey       bool        `json:"forKey,omitempty"`       // True if the violation is for a map key rather than its value
 }
 
 // ValidationService handles proto validation using dynamic messages
 type ValidationService struct {
-	validator        protovalidate.Validator
-	schemaSourceMode config.SchemaSourceMode
-	bsrOrg           string
-	bsrModule        string
-	bsrToken         string
-	httpClient       *http.Client
+	validator   protovalidate.Validator
+	descriptors DescriptorSource
+	strictMode  bool
 }
 
 // NewValidationService creates a new validation service instance
-func NewValidationService(validator protovalidate.Validator, schemaSourceMode config.SchemaSourceMode, bsrOrg, bsrModule, bsrToken string) *ValidationService {
-	logger.Debug("Initializing ValidationService with mode=%d, org=%s, module=%s", schemaSourceMode, bsrOrg, bsrModule)
+// descriptors resolves schema names to message descriptors, see NewDescriptorSourceForMode
+// strictMode is the deployment default for reporting unknown fields, requests may override it
+func NewValidationService(validator protovalidate.Validator, descriptors DescriptorSource, strictMode bool) *ValidationService {
+	logger.Debug("Initializing ValidationService with source=%s, strict=%v", descriptors.Name(), strictMode)
 	return &ValidationService{
-		validator:        validator,
-		schemaSourceMode: schemaSourceMode,
-		bsrOrg:           bsrOrg,
-		bsrModule:        bsrModule,
-		bsrToken:         bsrToken,
-		httpClient:       &http.Client{},
+		validator:   validator,
+		descriptors: descriptors,
+		strictMode:  strictMode,
 	}
 }
 
-// GetFileDescriptorSetRequest represents the request body for BSR Reflection API
-type GetFileDescriptorSetRequest struct {
-	Module  string   `json:"module"`
-	Version string   `json:"version,omitempty"`
-	Symbols []string `json:"symbols,omitempty"`
+// CacheStats returns a snapshot of the descriptor cache counters
+// The counters are zero if the descriptor source does not cache
+func (s *ValidationService) CacheStats() DescriptorCacheStats {
+	if cached, ok := s.descriptors.(cacheStatsReporter); ok {
+		return cached.CacheStats()
+	}
+	return DescriptorCacheStats{}
 }
 
-// GetFileDescriptorSetResponse represents the response from BSR Reflection API
-// The fileDescriptorSet field is a JSON object that needs to be unmarshaled separately
-type GetFileDescriptorSetResponse struct {
-	FileDescriptorSet json.RawMessage `json:"fileDescriptorSet"`
-	Version           string          `json:"version,omitempty"`
+// ValidateProto validates a JSON payload against a protobuf message definition
+// Returns success status, array of validation errors, and any processing error
+// commit is the commit ID to use when fetching from BSR (defaults to "main" if empty)
+func (s *ValidationService) ValidateProto(schemaName string, jsonPayload []byte, commit string) (bool, []ValidationError, error) {
+	success, errors, _, err := s.ValidatePayload(schemaName, jsonPayload, commit, ValidateOptions{Format: PayloadFormatJSON})
+	return success, errors, err
 }
 
-// fetchDescriptorFromBSR fetches the FileDescriptorSet from BSR using the Reflection API
-// and returns a *protoregistry.Files
-// schemaName is the fully qualified message name (e.g., "proto.Task") to include in symbols
-// commit is the commit ID to use (defaults to "main" if empty)
-func (s *ValidationService) fetchDescriptorFromBSR(schemaName string, commit string) (*protoregistry.Files, error) {
-	// Build module name in format: buf.build/{org}/{module}
-	moduleName := fmt.Sprintf("buf.build/%s/%s", s.bsrOrg, s.bsrModule)
-
-	// Use provided commit, or fallback to environment variable, or default to "main"
-	version := commit
-	if version == "" {
-		version = config.GetEnv("BSR_VERSION", "main")
-	}
-
-	// Build request body with symbols (fully qualified message name)
-	requestBody := GetFileDescriptorSetRequest{
-		Module:  moduleName,
-		Version: version,
-		Symbols: []string{schemaName}, // Include the fully qualified message name
-	}
-
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		logger.Error("Failed to marshal request body: %v", err)
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	// Build BSR Reflection API URL
-	url := "https://buf.build/buf.reflect.v1beta1.FileDescriptorSetService/GetFileDescriptorSet"
-
-	// Log URL and request body in debug mode
-	logger.Debug("BSR Reflection API URL: %s", url)
-	logger.Debug("BSR Reflection API Request Body: %s", string(jsonBody))
-	logger.Debug("Fetching descriptor from BSR Reflection API: module=%s, version=%s, symbols=%v", moduleName, version, requestBody.Symbols)
-
-	// Create HTTP POST request
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		logger.Error("Failed to create HTTP request for URL %s: %v", url, err)
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	if s.bsrToken != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.bsrToken))
-		logger.Debug("Added Bearer token to BSR request")
-	}
-
-	// Execute the request
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		logger.Error("HTTP POST request failed for URL %s: %v", url, err)
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	logger.Debug("BSR HTTP response status: %d %s", resp.StatusCode, resp.Status)
-
-	if resp.StatusCode == http.StatusNotFound {
-		logger.Debug("Descriptor not found in BSR (404)")
-		return nil, fmt.Errorf("descriptor not found in BSR")
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		// Try to read error body for better error messages
-		errorBody, _ := io.ReadAll(resp.Body)
-		logger.Error("BSR returned unexpected status code %d: %s", resp.StatusCode, string(errorBody))
-		return nil, fmt.Errorf("BSR returned status code %d", resp.StatusCode)
-	}
+// ValidatePayload validates a payload in the format given by opts (JSON, binary or text)
+// against a protobuf message definition
+// Returns success status, array of validation errors, where the definition was resolved,
+// and any processing error
+// commit is the commit ID to use when fetching from BSR (defaults to "main" if empty)
+func (s *ValidationService) ValidatePayload(schemaName string, payload []byte, commit string, opts ValidateOptions) (bool, []ValidationError, Resolution, error) {
+	logger.Debug("ValidatePayload called for schemaName=%s, format=%s, commit=%s", schemaName, opts.Format, commit)
 
-	// Read JSON response
-	data, err := io.ReadAll(resp.Body)
+	resolved, err := s.ResolveMessageDescriptor(schemaName, commit)
 	if err != nil {
-		logger.Error("Failed to read BSR response body: %v", err)
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return false, nil, Resolution{}, err
 	}
 
-	logger.Debug("Successfully read BSR response body (size: %d bytes)", len(data))
+	success, errors, err := s.ValidateMessage(resolved, payload, opts)
+	return success, errors, resolved.Resolution(), err
+}
 
-	// Parse JSON response
-	var apiResponse GetFileDescriptorSetResponse
-	if err := json.Unmarshal(data, &apiResponse); err != nil {
-		logger.Error("Failed to unmarshal JSON response: %v", err)
-		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+// ResolveMessageDescriptor finds the message descriptor for schemaName in the descriptor source
+// commit is the commit ID or label to resolve (defaults to "main" if empty)
+// The result records which source satisfied the lookup; its registry is the only one used
+// to decode payloads, so a compiled-in type never shadows a fetched commit
+// Callers validating many payloads against the same schema should resolve once and
+// reuse the result with ValidateMessage
+func (s *ValidationService) ResolveMessageDescriptor(schemaName string, commit string) (*ResolvedMessage, error) {
+	// Set default commit to "main" if not provided
+	if commit == "" {
+		commit = "main"
 	}
 
-	if len(apiResponse.FileDescriptorSet) == 0 {
-		logger.Error("FileDescriptorSet is empty in API response")
-		return nil, fmt.Errorf("FileDescriptorSet is empty in API response")
+	logger.Debug("Resolving descriptor for %s at %s from source=%s", schemaName, commit, s.descriptors.Name())
+	resolved, err := s.descriptors.FindMessage(schemaName, commit)
+	if errors.Is(err, ErrVersionUnavailable) {
+		logger.Debug("Commit %s not available for schemaName=%s: %v", commit, schemaName, err)
+		return nil, fmt.Errorf("commit %s is not available for %s: %w", commit, schemaName, err)
 	}
-
-	// Unmarshal FileDescriptorSet from JSON using protojson
-	var fds descriptorpb.FileDescriptorSet
-	unmarshalOpts := protojson.UnmarshalOptions{
-		DiscardUnknown: true,
+	if errors.Is(err, ErrNotFound) {
+		logger.Debug("Descriptor not found for schemaName=%s: %v", schemaName, err)
+		return nil, fmt.Errorf("unknown schema name: %s: %w", schemaName, ErrNotFound)
 	}
-	if err := unmarshalOpts.Unmarshal(apiResponse.FileDescriptorSet, &fds); err != nil {
-		logger.Error("Failed to unmarshal FileDescriptorSet from JSON: %v", err)
-		return nil, fmt.Errorf("failed to unmarshal FileDescriptorSet: %w", err)
+	if errors.Is(err, ErrInvalidArgument) {
+		logger.Debug("Invalid schema name %s: %v", schemaName, err)
+		return nil, err
 	}
-
-	// Convert FileDescriptorSet to *protoregistry.Files
-	files, err := protodesc.NewFiles(&fds)
 	if err != nil {
-		logger.Error("Failed to create Files from FileDescriptorSet: %v", err)
-		return nil, fmt.Errorf("failed to create Files: %w", err)
+		logger.Debug("Failed to resolve descriptor for schemaName=%s: %v", schemaName, err)
+		return nil, fmt.Errorf("%w: failed to resolve descriptor for %s: %w", ErrSourceUnavailable, schemaName, err)
 	}
 
-	logger.Debug("Successfully created Files from BSR descriptor (version: %s)", apiResponse.Version)
-	return files, nil
+	logger.Info("Resolved descriptor for %s at %s from source=%s (commit=%s, digest=%s)", schemaName, commit, resolved.Source, resolved.Commit, resolved.Digest)
+	return resolved, nil
 }
 
-// findMessageDescriptor finds a message descriptor by fully qualified name
-// It tries the provided files first, then falls back to GlobalFiles
-func (s *ValidationService) findMessageDescriptor(schemaName string, files *protoregistry.Files) (protoreflect.MessageDescriptor, error) {
-	fullName := protoreflect.FullName(schemaName)
-
-	// Try to find in provided files first
-	if files != nil {
-		desc, err := files.FindDescriptorByName(fullName)
-		if err == nil {
-			if md, ok := desc.(protoreflect.MessageDescriptor); ok {
-				logger.Debug("Found message descriptor in provided files: %s", schemaName)
-				return md, nil
-			}
-		}
+// ValidateMessage validates a payload against an already resolved message descriptor
+// Returns success status, array of validation errors, and any processing error
+func (s *ValidationService) ValidateMessage(resolved *ResolvedMessage, payload []byte, opts ValidateOptions) (bool, []ValidationError, error) {
+	md := resolved.Descriptor
+	types := resolved.Types()
+	schemaName := string(md.FullName())
+	format := opts.Format
+	strict := s.strictMode
+	if opts.Strict != nil {
+		strict = *opts.Strict
 	}
 
-	// Fallback to GlobalFiles
-	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(fullName)
-	if err != nil {
-		return nil, err
-	}
+	// Step 1: Create dynamic message
+	msg := dynamicpb.NewMessage(md)
+	logger.Debug("Created dynamic message for schemaName=%s", schemaName)
 
-	md, ok := desc.(protoreflect.MessageDescriptor)
-	if !ok {
-		return nil, fmt.Errorf("schema name %s does not refer to a message", schemaName)
+	// Step 2: Report undefined enum names instead of dropping them, and in strict mode
+	// unknown JSON fields too
+	var errors []ValidationError
+	if format == PayloadFormatJSON {
+		if strict {
+			errors = append(errors, findUnknownJSONFields(md, payload)...)
+		} else {
+			errors = append(errors, findUndefinedJSONEnums(md, payload)...)
+		}
 	}
 
-	logger.Debug("Found message descriptor in GlobalFiles: %s", schemaName)
-	return md, nil
-}
-
-// ValidateProto validates a JSON payload against a protobuf message definition
-// Returns success status, array of validation errors, and any processing error
-// commit is the commit ID to use when fetching from BSR (defaults to "main" if empty)
-func (s *ValidationService) ValidateProto(schemaName string, jsonPayload []byte, commit string) (bool, []ValidationError, error) {
-	// Set default commit to "main" if not provided
-	if commit == "" {
-		commit = "main"
-	}
-	logger.Debug("ValidateProto called for schemaName=%s, commit=%s, mode=%d", schemaName, commit, s.schemaSourceMode)
-
-	var md protoreflect.MessageDescriptor
-	var err error
-
-	// Step 1: Find message descriptor based on mode
-	if s.schemaSourceMode == config.BSROnly {
-		// BSROnly: Always fetch from BSR
-		logger.Debug("BSROnly mode: fetching descriptor from BSR for %s", schemaName)
-		files, err := s.fetchDescriptorFromBSR(schemaName, commit)
-		if err != nil {
-			logger.Debug("Failed to fetch descriptor from BSR for schemaName=%s: %v", schemaName, err)
-			return false, nil, fmt.Errorf("failed to fetch descriptor from BSR: %w", err)
-		}
-		md, err = s.findMessageDescriptor(schemaName, files)
-		if err != nil {
-			logger.Debug("Failed to find descriptor in BSR files for schemaName=%s: %v", schemaName, err)
-			return false, nil, fmt.Errorf("unknown schema name: %s", schemaName)
-		}
-	} else if s.schemaSourceMode == config.LocalOnly {
-		// LocalOnly: Only use GlobalFiles
-		logger.Debug("LocalOnly mode: checking GlobalFiles for %s", schemaName)
-		md, err = s.findMessageDescriptor(schemaName, nil)
-		if err != nil {
-			logger.Debug("Failed to find descriptor in GlobalFiles for schemaName=%s: %v", schemaName, err)
-			return false, nil, fmt.Errorf("unknown schema name: %s", schemaName)
-		}
-	} else {
-		// LocalThenBSR: Try local first, then fallback to BSR
-		logger.Debug("LocalThenBSR mode: checking GlobalFiles first for %s", schemaName)
-		md, err = s.findMessageDescriptor(schemaName, nil)
-		if err != nil {
-			logger.Debug("Not found in GlobalFiles, fetching from BSR for schemaName=%s", schemaName)
-			// Fallback to BSR
-			files, bsrErr := s.fetchDescriptorFromBSR(schemaName, commit)
-			if bsrErr != nil {
-				logger.Debug("Failed to fetch descriptor from BSR for schemaName=%s: %v", schemaName, bsrErr)
-				return false, nil, fmt.Errorf("unknown schema name: %s (local and BSR lookup failed)", schemaName)
-			}
-			md, err = s.findMessageDescriptor(schemaName, files)
-			if err != nil {
-				logger.Debug("Failed to find descriptor in BSR files for schemaName=%s: %v", schemaName, err)
-				return false, nil, fmt.Errorf("unknown schema name: %s", schemaName)
+	// Step 3: Unmarshal payload to dynamic message
+	if err := unmarshalPayload(payload, format, strict, types, msg); err != nil {
+		logger.Debug("Failed to unmarshal %s payload for schemaName=%s: %v", format, schemaName, err)
+		// Values of the wrong type are reported like violations, with the path of the bad value
+		if format == PayloadFormatJSON {
+			if decodeErrors := findInvalidJSONValues(md, payload, types, err); len(decodeErrors) > 0 {
+				logger.Info("Validation failed for schemaName=%s with %d decode error(s)", schemaName, len(decodeErrors))
+				return false, append(errors, decodeErrors...), nil
 			}
 		}
+		return false, nil, fmt.Errorf("%w: failed to unmarshal %s: %w", ErrInvalidArgument, format, err)
 	}
+	logger.Debug("Successfully unmarshaled %s payload for schemaName=%s", format, schemaName)
 
-	// Step 2: Create dynamic message
-	msg := dynamicpb.NewMessage(md)
-	logger.Debug("Created dynamic message for schemaName=%s", schemaName)
-
-	// Step 3: Unmarshal JSON to dynamic message
-	unmarshalOpts := protojson.UnmarshalOptions{
-		DiscardUnknown: true, // Ignore unknown fields
+	if strict && format == PayloadFormatBinary {
+		errors = append(errors, findUnknownWireFields(msg)...)
 	}
-	if err := unmarshalOpts.Unmarshal(jsonPayload, msg); err != nil {
-		logger.Debug("Failed to unmarshal JSON for schemaName=%s: %v", schemaName, err)
-		return false, nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	if len(errors) > 0 {
+		logger.Debug("Found %d unknown field(s) or enum value(s) for schemaName=%s", len(errors), schemaName)
 	}
-	logger.Debug("Successfully unmarshaled JSON for schemaName=%s", schemaName)
 
 	// Step 4: Validate using protovalidate
 	if err := s.validator.Validate(msg); err != nil {
 		logger.Debug("Validation failed for schemaName=%s: %v", schemaName, err)
 
 		// Step 5: Collect validation errors
-		var errors []ValidationError
 		if validationErr, ok := err.(*protovalidate.ValidationError); ok {
 			// protovalidate.ValidationError contains detailed error information
-			errors = s.collectValidationErrors(validationErr)
+			errors = append(errors, withoutDroppedValueViolations(errors, s.collectValidationErrors(md, validationErr))...)
 		} else {
 			// Fallback to simple error message
 			technical := err.Error()
-			errors = []ValidationError{
-				{
-					Friendly:  s.makeFriendlyError(technical),
-					Technical: technical,
-				},
-			}
+			errors = append(errors, ValidationError{
+				Friendly:  s.makeFriendlyError(technical),
+				Technical: technical,
+			})
 		}
+	}
 
+	if len(errors) > 0 {
 		logger.Info("Validation failed for schemaName=%s with %d error(s)", schemaName, len(errors))
 		return false, errors, nil
 	}
@@ -305,7 +194,8 @@ func (s *ValidationService) ValidateProto(schemaName string, jsonPayload []byte,
 }
 
 // collectValidationErrors extracts error messages from a ValidationError and formats them
-func (s *ValidationService) collectValidationErrors(err *protovalidate.ValidationError) []ValidationError {
+// md is the validated message descriptor, used to resolve JSON field names
+func (s *ValidationService) collectValidationErrors(md protoreflect.MessageDescriptor, err *protovalidate.ValidationError) []ValidationError {
 	var errors []ValidationError
 
 	// Add the main violation message
@@ -340,10 +230,20 @@ func (s *ValidationService) collectValidationErrors(err *protovalidate.Validatio
 				}
 			}
 
-			errors = append(errors, ValidationError{
+			validationError := ValidationError{
 				Friendly:  friendly,
 				Technical: technical,
-			})
+			}
+			if proto != nil {
+				validationError.Field = protovalidate.FieldPathString(proto.GetField())
+				validationError.JSONField = jsonFieldPathString(md, proto.GetField())
+				validationError.Pointer = pointerTo(jsonPointer(md, proto.GetField()))
+				validationError.RuleID = proto.GetRuleId()
+				validationError.ConstraintID = violationConstraintID(proto)
+				validationError.Value = violationValue(violation)
+				validationError.ForKey = proto.GetForKey()
+			}
+			errors = append(errors, validationError)
 		}
 	}
 
@@ -361,6 +261,38 @@ func (s *ValidationService) collectValidationErrors(err *protovalidate.Validatio
 	return errors
 }
 
+// withoutDroppedValueViolations removes the "required" violations of fields whose value was
+// reported as an undefined enum name in decodeErrors: the value was sent but dropped while
+// decoding, so the invalid_enum error is the one that explains it
+func withoutDroppedValueViolations(decodeErrors, violations []ValidationError) []ValidationError {
+	dropped := make(map[string]bool)
+	for _, e := range decodeErrors {
+		if e.RuleID == jsonInvalidEnumRuleID && e.Pointer != nil {
+			dropped[*e.Pointer] = true
+		}
+	}
+	if len(dropped) == 0 {
+		return violations
+	}
+
+	kept := violations[:0]
+	for _, v := range violations {
+		if v.RuleID == "required" && v.Pointer != nil && dropped[*v.Pointer] {
+			continue
+		}
+		kept = append(kept, v)
+	}
+	return kept
+}
+
+// MessageValidationErrors converts the protovalidate error for msg into ValidationErrors,
+// in the same shape the validation API reports them
+// Used by handlers that validate generated request messages directly
+func MessageValidationErrors(msg protoreflect.ProtoMessage, err *protovalidate.ValidationError) []ValidationError {
+	// The error formatting helpers do not depend on the service configuration
+	return (&ValidationService{}).collectValidationErrors(msg.ProtoReflect().Descriptor(), err)
+}
+
 // makeFriendlyError attempts to create a human-friendly error message from a technical error
 func (s *ValidationService) makeFriendlyError(technical string) string {
 	// Check if it's a CEL compilation error
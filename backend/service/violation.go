@@ -0,0 +1,216 @@
+package service
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"buf.build/go/protovalidate"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// celRuleName is the rule path element used by protovalidate for custom CEL constraints
+const celRuleName = "cel"
+
+// jsonFieldNames resolves the protojson (lowerCamelCase) name of every element of a violation
+// field path against md. Extension fields and elements that cannot be resolved keep their proto names
+func jsonFieldNames(md protoreflect.MessageDescriptor, path *validate.FieldPath) []string {
+	elements := path.GetElements()
+	names := make([]string, 0, len(elements))
+	for _, element := range elements {
+		var fd protoreflect.FieldDescriptor
+		if md != nil {
+			fd = md.Fields().ByNumber(protoreflect.FieldNumber(element.GetFieldNumber()))
+		}
+		if fd != nil {
+			names = append(names, fd.JSONName())
+		} else {
+			names = append(names, element.GetFieldName())
+		}
+		md = nextMessageDescriptor(fd, element)
+	}
+	return names
+}
+
+// jsonFieldPathString renders a violation field path like protovalidate.FieldPathString,
+// but using the protojson field names resolved against md
+func jsonFieldPathString(md protoreflect.MessageDescriptor, path *validate.FieldPath) string {
+	names := jsonFieldNames(md, path)
+
+	var result strings.Builder
+	for i, element := range path.GetElements() {
+		if i > 0 {
+			result.WriteByte('.')
+		}
+		result.WriteString(names[i])
+		if subscript := fieldPathSubscript(element); subscript != "" {
+			result.WriteByte('[')
+			result.WriteString(subscript)
+			result.WriteByte(']')
+		}
+	}
+	return result.String()
+}
+
+// jsonPointer renders a violation field path as an RFC 6901 JSON Pointer into the JSON payload,
+// e.g. "/items/3/discount", matching the instance paths reported by JSON Schema validators
+// A violation on the root message yields the empty pointer ""
+func jsonPointer(md protoreflect.MessageDescriptor, path *validate.FieldPath) string {
+	names := jsonFieldNames(md, path)
+
+	var result strings.Builder
+	for i, element := range path.GetElements() {
+		result.WriteByte('/')
+		result.WriteString(escapeJSONPointerToken(names[i]))
+		if element.WhichSubscript() == validate.FieldPathElement_Subscript_not_set_case {
+			continue
+		}
+		result.WriteByte('/')
+		if element.WhichSubscript() == validate.FieldPathElement_StringKey_case {
+			// Map keys are JSON object keys, so they are not quoted
+			result.WriteString(escapeJSONPointerToken(element.GetStringKey()))
+		} else {
+			result.WriteString(fieldPathSubscript(element))
+		}
+	}
+	return result.String()
+}
+
+// pointerTo returns a ValidationError.Pointer located at pointer, which may be the root ""
+func pointerTo(pointer string) *string {
+	return &pointer
+}
+
+// jsonPointerEscaper escapes "~" and "/" in JSON Pointer reference tokens (RFC 6901 section 3)
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// escapeJSONPointerToken escapes a single JSON Pointer reference token
+func escapeJSONPointerToken(token string) string {
+	return jsonPointerEscaper.Replace(token)
+}
+
+// fieldPathSubscript renders the list index or map key of a path element, or "" if it has none
+func fieldPathSubscript(element *validate.FieldPathElement) string {
+	switch element.WhichSubscript() {
+	case validate.FieldPathElement_Index_case:
+		return strconv.FormatUint(element.GetIndex(), 10)
+	case validate.FieldPathElement_BoolKey_case:
+		return strconv.FormatBool(element.GetBoolKey())
+	case validate.FieldPathElement_IntKey_case:
+		return strconv.FormatInt(element.GetIntKey(), 10)
+	case validate.FieldPathElement_UintKey_case:
+		return strconv.FormatUint(element.GetUintKey(), 10)
+	case validate.FieldPathElement_StringKey_case:
+		return strconv.Quote(element.GetStringKey())
+	default:
+		return ""
+	}
+}
+
+// nextMessageDescriptor returns the message descriptor that the next path element is resolved against
+// For map fields with a subscript this is the map value type, otherwise the field's own message type
+func nextMessageDescriptor(fd protoreflect.FieldDescriptor, element *validate.FieldPathElement) protoreflect.MessageDescriptor {
+	if fd == nil {
+		return nil
+	}
+	if fd.IsMap() {
+		if element.WhichSubscript() != validate.FieldPathElement_Subscript_not_set_case {
+			return fd.MapValue().Message()
+		}
+		return nil
+	}
+	return fd.Message()
+}
+
+// violationConstraintID returns the custom CEL constraint id of a violation, or "" for standard rules
+// Field-level CEL constraints have a rule path ending in "cel", message-level ones have no rule path
+func violationConstraintID(violation *validate.Violation) string {
+	elements := violation.GetRule().GetElements()
+	if len(elements) > 0 && elements[len(elements)-1].GetFieldName() != celRuleName {
+		return ""
+	}
+	return violation.GetRuleId()
+}
+
+// violationValue converts the offending value of a violation into a JSON-friendly value
+// following protojson conventions (enum names, 64-bit integers as strings, messages as JSON)
+// Returns nil if the violation carries no value
+func violationValue(violation *protovalidate.Violation) interface{} {
+	fd := violation.FieldDescriptor
+	value := violation.FieldValue
+	if fd == nil || !value.IsValid() {
+		return nil
+	}
+
+	switch v := value.Interface().(type) {
+	case protoreflect.List:
+		elements := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elements = append(elements, scalarValue(fd, v.Get(i)))
+		}
+		return elements
+	case protoreflect.Map:
+		entries := make(map[string]interface{}, v.Len())
+		v.Range(func(key protoreflect.MapKey, val protoreflect.Value) bool {
+			entries[key.String()] = scalarValue(fd.MapValue(), val)
+			return true
+		})
+		return entries
+	default:
+		// For map fields a single key or value is reported, use the matching descriptor
+		if fd.IsMap() {
+			if violation.Proto.GetForKey() {
+				return scalarValue(fd.MapKey(), value)
+			}
+			return scalarValue(fd.MapValue(), value)
+		}
+		return scalarValue(fd, value)
+	}
+}
+
+// scalarValue converts a single (non-list, non-map) field value into a JSON-friendly value
+func scalarValue(fd protoreflect.FieldDescriptor, value protoreflect.Value) interface{} {
+	switch v := value.Interface().(type) {
+	case protoreflect.Message:
+		data, err := protojson.Marshal(v.Interface())
+		if err != nil {
+			return nil
+		}
+		return json.RawMessage(data)
+	case protoreflect.EnumNumber:
+		if fd.Enum() != nil {
+			if enumValue := fd.Enum().Values().ByNumber(v); enumValue != nil {
+				return string(enumValue.Name())
+			}
+		}
+		return int32(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float32:
+		return floatValue(float64(v))
+	case float64:
+		return floatValue(v)
+	default:
+		// bool, int32, uint32, string and []byte (base64 encoded by encoding/json)
+		return v
+	}
+}
+
+// floatValue converts non-finite floats into the strings used by protojson
+func floatValue(v float64) interface{} {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "Infinity"
+	case math.IsInf(v, -1):
+		return "-Infinity"
+	default:
+		return v
+	}
+}
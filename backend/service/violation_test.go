@@ -0,0 +1,60 @@
+package service
+
+import (
+	"testing"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestJSONPointer(t *testing.T) {
+	md := (&structpb.Struct{}).ProtoReflect().Descriptor()
+
+	tests := []struct {
+		name     string
+		elements []*validate.FieldPathElement
+		want     string
+	}{
+		{
+			name: "root",
+			want: "",
+		},
+		{
+			name: "map key is unquoted",
+			elements: []*validate.FieldPathElement{
+				validate.FieldPathElement_builder{FieldNumber: ptr(int32(1)), FieldName: ptr("fields"), StringKey: ptr("email")}.Build(),
+			},
+			want: "/fields/email",
+		},
+		{
+			name: "map key is escaped",
+			elements: []*validate.FieldPathElement{
+				validate.FieldPathElement_builder{FieldNumber: ptr(int32(1)), FieldName: ptr("fields"), StringKey: ptr("a/b~c")}.Build(),
+			},
+			want: "/fields/a~1b~0c",
+		},
+		{
+			name: "nested map value and list index",
+			elements: []*validate.FieldPathElement{
+				validate.FieldPathElement_builder{FieldNumber: ptr(int32(1)), FieldName: ptr("fields"), StringKey: ptr("tags")}.Build(),
+				validate.FieldPathElement_builder{FieldNumber: ptr(int32(6)), FieldName: ptr("list_value")}.Build(),
+				validate.FieldPathElement_builder{FieldNumber: ptr(int32(1)), FieldName: ptr("values"), Index: ptr(uint64(3))}.Build(),
+				validate.FieldPathElement_builder{FieldNumber: ptr(int32(3)), FieldName: ptr("string_value")}.Build(),
+			},
+			want: "/fields/tags/listValue/values/3/stringValue",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := validate.FieldPath_builder{Elements: tt.elements}.Build()
+			if got := jsonPointer(md, path); got != tt.want {
+				t.Errorf("Expected pointer %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -37,7 +37,9 @@ export function SchemaForm({ fullyQualifiedName, commits: initialCommits }: Sche
       setValidationResult(null);
 
       try {
-        const fetchedSchema = await fetchSchema(fullyQualifiedName);
+        // Render the form for the same commit the payload is validated against
+        const commitToUse = selectedCommit === 'main' ? undefined : selectedCommit;
+        const fetchedSchema = await fetchSchema(fullyQualifiedName, commitToUse);
         if (!cancelled) {
           setSchema(fetchedSchema);
           setLoading(false);
@@ -55,7 +57,7 @@ export function SchemaForm({ fullyQualifiedName, commits: initialCommits }: Sche
     return () => {
       cancelled = true;
     };
-  }, [fullyQualifiedName]);
+  }, [fullyQualifiedName, selectedCommit]);
 
   const handleSubmit = (data: IChangeEvent<any, JSONSchema7, any>, event: any) => {
     // RJSF only calls onSubmit when form is valid
@@ -290,7 +292,7 @@ export function SchemaForm({ fullyQualifiedName, commits: initialCommits }: Sche
   const handleRetry = () => {
     setError(null);
     setLoading(true);
-    fetchSchema(fullyQualifiedName)
+    fetchSchema(fullyQualifiedName, selectedCommit === 'main' ? undefined : selectedCommit)
       .then((fetchedSchema) => {
         setSchema(fetchedSchema);
         setLoading(false);
@@ -56,14 +56,18 @@ export async function fetchProtoFiles(): Promise<ProtoFile[]> {
 }
 
 export async function fetchSchema(
-  fullyQualifiedName: string
+  fullyQualifiedName: string,
+  commit?: string
 ): Promise<JSONSchema7> {
-  const url = `${API_BASE_URL}/v1/schema/${encodeURIComponent(
-    fullyQualifiedName
-  )}`;
+  const url = new URL(
+    `${API_BASE_URL}/v1/schema/${encodeURIComponent(fullyQualifiedName)}`
+  );
+  if (commit) {
+    url.searchParams.set("commit", commit);
+  }
 
   try {
-    const response = await fetch(url);
+    const response = await fetch(url.toString());
 
     if (!response.ok) {
       const errorText = await response.text().catch(() => "Unknown error");
@@ -100,6 +104,13 @@ export async function fetchSchema(
 export interface ValidationError {
   friendly: string; // Human-readable message
   technical: string; // Original technical error
+  field?: string; // Field path using proto names
+  jsonField?: string; // Field path using JSON names
+  pointer?: string; // RFC 6901 JSON Pointer into the payload, matches JSON Schema instance paths ("" is the root, absent if the error has no location)
+  ruleId?: string; // Violated rule, e.g. "string.min_len"
+  constraintId?: string; // Custom CEL constraint id
+  value?: unknown; // Offending value, if any
+  forKey?: boolean; // True if the violation is for a map key rather than its value
 }
 
 export interface ValidateProtoResponse {
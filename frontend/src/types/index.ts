@@ -17,6 +17,13 @@ export interface ApiError {
 export interface ValidationError {
   friendly: string;  // Human-readable message
   technical: string;  // Original technical error
+  field?: string;  // Field path using proto names, e.g. "contact_info.country_code"
+  jsonField?: string;  // Field path using JSON names, e.g. "contactInfo.countryCode"
+  pointer?: string;  // RFC 6901 JSON Pointer into the payload, e.g. "/items/3/discount" ("" is the root, absent if the error has no location)
+  ruleId?: string;  // Violated rule, e.g. "string.min_len"
+  constraintId?: string;  // Custom CEL constraint id, e.g. "comment_required_if_blocked"
+  value?: unknown;  // Offending value, if any
+  forKey?: boolean;  // True if the violation is for a map key rather than its value
 }
 
 export interface ValidationResult {